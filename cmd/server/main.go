@@ -1,12 +1,18 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
 	"movie-ticket/config"
 	"movie-ticket/infra/postgres"
 	redis_config "movie-ticket/infra/redis"
 	"movie-ticket/internal/router"
+	"movie-ticket/internal/worker"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	_ "movie-ticket/docs"
@@ -31,8 +37,20 @@ func main() {
 	postgres.InitDB()
 	redis_config.InitRedis()
 
+	// Admin pertama dibuat dari env ADMIN_EMAIL/ADMIN_PASSWORD jika belum ada admin sama sekali
+	authSvc := router.NewAuthService()
+	if err := authSvc.EnsureAdmin(config.Get("ADMIN_EMAIL"), config.Get("ADMIN_PASSWORD")); err != nil {
+		log.Printf("Gagal membuat admin awal: %v", err)
+	}
+
 	r := gin.Default()
 
+	// X-Forwarded-For hanya dipercaya dari proxy di TRUSTED_PROXIES (IP/CIDR dipisah koma).
+	// Tanpa env ini IP client diambil dari koneksi langsung.
+	if err := r.SetTrustedProxies(config.GetList("TRUSTED_PROXIES")); err != nil {
+		log.Fatalf("TRUSTED_PROXIES tidak valid: %v", err)
+	}
+
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"*"},
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
@@ -53,10 +71,37 @@ func main() {
 		})
 	})
 
+	// Worker untuk meng-expire reservasi PENDING yang lewat batas waktu
+	expiryWorker := worker.NewReservationExpiryWorker(
+		router.NewReservationService(),
+		redis_config.RedisClient,
+		config.GetDuration("RESERVATION_EXPIRY_INTERVAL", 30*time.Second),
+	)
+	expiryWorker.Start()
+
 	address := "0.0.0.0:" + config.Get("PORT")
-	log.Printf("Server berjalan di %s", address)
+	srv := &http.Server{
+		Addr:    address,
+		Handler: r,
+	}
+
+	go func() {
+		log.Printf("Server berjalan di %s", address)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Gagal menjalankan server: %v", err)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+	log.Println("Server dimatikan...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
-	if err := r.Run(address); err != nil {
-		log.Fatalf("Gagal menjalankan server: %v", err)
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Printf("Gagal mematikan server dengan bersih: %v", err)
 	}
+	expiryWorker.Stop()
 }
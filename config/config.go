@@ -3,10 +3,14 @@ package config
 import (
 	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
+const defaultTimezone = "Asia/Jakarta"
+
 func LoadEnv() {
 	if err := godotenv.Load(".env"); err != nil {
 		log.Println("ENV not found!")
@@ -16,3 +20,55 @@ func LoadEnv() {
 func Get(key string) string {
 	return os.Getenv(key)
 }
+
+// GetOrDefault mengembalikan nilai env atau fallback jika env kosong
+func GetOrDefault(key, fallback string) string {
+	if val := os.Getenv(key); val != "" {
+		return val
+	}
+	return fallback
+}
+
+// IsDevelopment bernilai true jika APP_ENV=development, default yang tidak aman hanya boleh dipakai di sini
+func IsDevelopment() bool {
+	return strings.EqualFold(os.Getenv("APP_ENV"), "development")
+}
+
+// Location mengembalikan timezone bioskop (APP_TIMEZONE), default Asia/Jakarta
+func Location() *time.Location {
+	loc, err := time.LoadLocation(GetOrDefault("APP_TIMEZONE", defaultTimezone))
+	if err != nil {
+		log.Printf("Invalid APP_TIMEZONE, fallback ke %s: %v", defaultTimezone, err)
+		loc, err = time.LoadLocation(defaultTimezone)
+		if err != nil {
+			return time.UTC
+		}
+	}
+	return loc
+}
+
+// GetDuration mem-parse env berformat durasi Go (misal 30s, 1m), fallback jika kosong/invalid
+func GetDuration(key string, fallback time.Duration) time.Duration {
+	val := os.Getenv(key)
+	if val == "" {
+		return fallback
+	}
+
+	d, err := time.ParseDuration(val)
+	if err != nil || d <= 0 {
+		log.Printf("Invalid %s, fallback ke %s: %v", key, fallback, err)
+		return fallback
+	}
+	return d
+}
+
+// GetList mem-parse env berisi daftar yang dipisah koma, item kosong diabaikan
+func GetList(key string) []string {
+	var list []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
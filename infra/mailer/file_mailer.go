@@ -0,0 +1,53 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fileMailer menulis setiap email sebagai file .eml di dir,
+// sehingga isi email (misal token reset) bisa dibaca saat development/testing.
+// File dan folder hanya bisa dibaca pemilik proses karena berisi token.
+type fileMailer struct {
+	dir  string
+	from string
+}
+
+func NewFileMailer(dir, from string) Mailer {
+	return &fileMailer{dir: dir, from: from}
+}
+
+func (m *fileMailer) Send(ctx context.Context, msg Message) error {
+	if err := os.MkdirAll(m.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create mail dir: %w", err)
+	}
+
+	now := time.Now()
+	name := fmt.Sprintf("%s_%s.eml", now.Format("20060102T150405"), uuid.NewString())
+
+	if err := os.WriteFile(filepath.Join(m.dir, name), []byte(buildMessage(m.from, msg, now)), 0o600); err != nil {
+		return fmt.Errorf("failed to write mail: %w", err)
+	}
+
+	return nil
+}
+
+// buildMessage menyusun email plain text dengan header minimal (RFC 5322)
+func buildMessage(from string, msg Message, date time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return b.String()
+}
@@ -0,0 +1,21 @@
+package mailer
+
+import (
+	"context"
+	"log"
+)
+
+// logMailer tidak mengirim email, hanya mencatat penerima dan subject ke log. Untuk development.
+// Isi email tidak dicetak karena berisi token (reset password, verifikasi email).
+type logMailer struct {
+	from string
+}
+
+func NewLogMailer(from string) Mailer {
+	return &logMailer{from: from}
+}
+
+func (m *logMailer) Send(ctx context.Context, msg Message) error {
+	log.Printf("📧 Mail from=%s to=%s subject=%q (body %d bytes not logged)", m.from, msg.To, msg.Subject, len(msg.Body))
+	return nil
+}
@@ -0,0 +1,49 @@
+package mailer
+
+import (
+	"context"
+	"log"
+	"movie-ticket/config"
+	"strings"
+)
+
+// Message adalah email plain text yang akan dikirim
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer mengirim email. Implementasi dipilih lewat env MAILER_DRIVER:
+// log (cetak ke stdout tanpa isi), file (tulis ke MAILER_FILE_DIR) atau smtp.
+// MAILER_DRIVER wajib diisi kecuali APP_ENV=development (default log).
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+func NewFromEnv() Mailer {
+	from := config.GetOrDefault("MAIL_FROM", "no-reply@movie-ticket.local")
+
+	driver := strings.ToLower(strings.TrimSpace(config.Get("MAILER_DRIVER")))
+	if driver == "" && config.IsDevelopment() {
+		driver = "log"
+	}
+
+	switch driver {
+	case "file":
+		return NewFileMailer(config.GetOrDefault("MAILER_FILE_DIR", "tmp/mails"), from)
+	case "smtp":
+		return NewSMTPMailer(
+			config.Get("SMTP_HOST"),
+			config.GetOrDefault("SMTP_PORT", "587"),
+			config.Get("SMTP_USER"),
+			config.Get("SMTP_PASS"),
+			from,
+		)
+	case "log":
+		return NewLogMailer(from)
+	default:
+		log.Fatalf("MAILER_DRIVER %q tidak valid, pilih log, file atau smtp", driver)
+		return nil
+	}
+}
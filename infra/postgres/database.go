@@ -4,12 +4,6 @@ import (
 	"log"
 	"movie-ticket/config"
 
-	// user "movie-ticket/internal/auth_module/entities"
-	// movie "movie-ticket/internal/movie_module/entities"
-	// reservation "movie-ticket/internal/reservation_module/entities"
-	// schedule "movie-ticket/internal/schedule_module/entities"
-	// studio "movie-ticket/internal/studio_module/entities"
-
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -34,15 +28,12 @@ func InitDB() {
 		log.Fatal("failed to connect database :", err)
 	}
 
-	// err = DB.AutoMigrate(
-	// 	&user.User{},
-	// 	&movie.Movies{},
-	// 	&studio.Studio{},
-	// 	&schedule.Schedules{},
-	// 	&reservation.Reservation{},
-	// 	&reservation.ReservationSeat{})
-	if err != nil {
-		log.Fatal("failed to migrate :", err)
+	// Migrasi hanya dijalankan jika DB_AUTO_MIGRATE=true
+	if config.Get("DB_AUTO_MIGRATE") == "true" {
+		if err := Migrate(DB); err != nil {
+			log.Fatal("failed to migrate :", err)
+		}
+		log.Println("✅ Migrasi database selesai")
 	}
 
 	log.Println("✅ Postgres terkoneksi")
@@ -0,0 +1,191 @@
+package postgres
+
+import (
+	"fmt"
+	"movie-ticket/config"
+
+	user "movie-ticket/internal/auth_module/entities"
+	movie "movie-ticket/internal/movie_module/entities"
+	payment "movie-ticket/internal/payment_module/entities"
+	reservation "movie-ticket/internal/reservation_module/entities"
+	schedule "movie-ticket/internal/schedule_module/entities"
+	studio "movie-ticket/internal/studio_module/entities"
+
+	"gorm.io/gorm"
+)
+
+// preMigrations dijalankan sebelum AutoMigrate, untuk perubahan skema
+// yang tidak bisa di-handle gorm (misal konversi tipe kolom)
+var preMigrations = []string{
+	// schedules.start_time/end_time dulu bertipe time (tanpa tanggal).
+	// Data lama dianggap tayang hari ini, dan end_time <= start_time berarti lewat tengah malam.
+	`DO $$
+	BEGIN
+		IF EXISTS (
+			SELECT 1 FROM information_schema.columns
+			WHERE table_name = 'schedules' AND column_name = 'start_time'
+			  AND data_type = 'time without time zone'
+		) THEN
+			ALTER TABLE schedules
+				ALTER COLUMN start_time TYPE timestamptz
+					USING (CURRENT_DATE + start_time)::timestamptz,
+				ALTER COLUMN end_time TYPE timestamptz
+					USING (CURRENT_DATE + end_time
+						+ CASE WHEN end_time <= start_time THEN interval '1 day' ELSE interval '0' END)::timestamptz;
+		END IF;
+	END $$;`,
+	// users.email_verified_at baru ditambahkan, akun yang sudah ada dianggap terverifikasi.
+	// Hanya dijalankan sekali saat kolom belum ada, akun baru setelahnya harus verifikasi.
+	`DO $$
+	BEGIN
+		IF EXISTS (
+			SELECT 1 FROM information_schema.tables WHERE table_name = 'users'
+		) AND NOT EXISTS (
+			SELECT 1 FROM information_schema.columns
+			WHERE table_name = 'users' AND column_name = 'email_verified_at'
+		) THEN
+			ALTER TABLE users ADD COLUMN email_verified_at timestamptz;
+			UPDATE users SET email_verified_at = created_at;
+		END IF;
+	END $$;`,
+	// movies.genre diperbesar untuk menampung beberapa nama genre. Kolom search_vector
+	// bergantung pada genre sehingga dihapus dulu, lalu dibuat ulang di postMigrations.
+	`DO $$
+	BEGIN
+		IF EXISTS (
+			SELECT 1 FROM information_schema.columns
+			WHERE table_name = 'movies' AND column_name = 'genre' AND character_maximum_length < 255
+		) THEN
+			ALTER TABLE movies DROP COLUMN IF EXISTS search_vector;
+			ALTER TABLE movies ALTER COLUMN genre TYPE varchar(255);
+		END IF;
+	END $$;`,
+	// movies.release_date/end_date baru ditambahkan. Movie lama dianggap tayang sejak dibuat,
+	// movie yang sudah dinonaktifkan dianggap selesai tayang pada hari terakhir diubah.
+	`DO $$
+	BEGIN
+		IF EXISTS (
+			SELECT 1 FROM information_schema.tables WHERE table_name = 'movies'
+		) AND NOT EXISTS (
+			SELECT 1 FROM information_schema.columns
+			WHERE table_name = 'movies' AND column_name = 'release_date'
+		) THEN
+			ALTER TABLE movies ADD COLUMN release_date date, ADD COLUMN end_date date;
+			UPDATE movies SET release_date = created_at::date,
+				end_date = CASE WHEN status THEN NULL ELSE GREATEST(created_at, updated_at)::date END;
+		END IF;
+	END $$;`,
+}
+
+// postMigrations dijalankan setelah AutoMigrate (index khusus, constraint, dll)
+var postMigrations = []string{
+	`CREATE INDEX IF NOT EXISTS idx_schedules_studio_time ON schedules (studio_id, start_time, end_time);`,
+
+	// reservation_seats.schedule_id diisi dari reservasi induknya untuk data lama
+	`UPDATE reservation_seats rs SET schedule_id = r.schedule_id
+		FROM reservations r
+		WHERE rs.reservation_id = r.id AND rs.schedule_id IS NULL;`,
+	// Kursi dari reservasi yang sudah tidak aktif tidak ikut constraint unik
+	`UPDATE reservation_seats rs SET released_at = r.updated_at
+		FROM reservations r
+		WHERE rs.reservation_id = r.id AND rs.released_at IS NULL
+		  AND (r.status IN ('CANCELED', 'EXPIRED') OR (r.status = 'PENDING' AND r.expires_at < NOW()));`,
+	// Satu kursi hanya boleh dimiliki satu reservasi aktif per jadwal
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_reservation_seats_active
+		ON reservation_seats (schedule_id, seat_code) WHERE released_at IS NULL;`,
+	// Satu reservasi hanya boleh punya satu payment yang tidak gagal
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_reservation_active
+		ON payments (reservation_id) WHERE status <> 'FAILED';`,
+	// Nomor telepon lama tanpa kode negara dinormalisasi ke E.164, nomor lokal 0xx ada di phoneBackfill
+	`UPDATE users SET phone_number = '+' || regexp_replace(phone_number, '[^0-9]', '', 'g')
+		WHERE phone_number !~ '^\+' AND regexp_replace(phone_number, '[^0-9]', '', 'g') ~ '^[1-9][0-9]{7,14}$';`,
+	// Email disimpan huruf kecil. Email lama diturunkan kapitalisasinya jika tidak bentrok dengan
+	// akun lain; jika masih ada duplikat beda kapitalisasi, index gagal dibuat dan harus dirapikan manual.
+	`UPDATE users u SET email = lower(u.email)
+		WHERE u.email <> lower(u.email)
+		  AND NOT EXISTS (SELECT 1 FROM users o WHERE o.id <> u.id AND lower(o.email) = lower(u.email));`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_lower ON users (lower(email));`,
+	// Pencarian movie: pg_trgm untuk judul yang salah ketik (butuh hak CREATE di database),
+	// search_vector dengan bobot judul (A), genre (B), deskripsi (C). Config 'simple' dipakai
+	// karena judul dan deskripsi bisa campuran bahasa Indonesia dan Inggris.
+	`CREATE EXTENSION IF NOT EXISTS pg_trgm;`,
+	`ALTER TABLE movies ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+		setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+		setweight(to_tsvector('simple', coalesce(genre, '')), 'B') ||
+		setweight(to_tsvector('simple', coalesce(description, '')), 'C')
+	) STORED;`,
+	`CREATE INDEX IF NOT EXISTS idx_movies_search_vector ON movies USING gin (search_vector);`,
+	// Index trigram untuk operator <% di pencarian judul
+	`CREATE INDEX IF NOT EXISTS idx_movies_title_trgm ON movies USING gin (title gin_trgm_ops);`,
+	// Genre lama berupa teks bebas (misal "Action, Comedy") dipecah menjadi baris di tabel genres
+	// dan dihubungkan lewat movie_genres. Hanya untuk movie yang belum punya relasi genre.
+	`INSERT INTO genres (id, name, slug, created_at, updated_at)
+		SELECT gen_random_uuid(), min(name), slug, NOW(), NOW() FROM (
+			SELECT left(initcap(trim(part)), 50) AS name,
+				left(trim(both '-' from regexp_replace(lower(trim(part)), '[^a-z0-9]+', '-', 'g')), 60) AS slug
+			FROM movies m, regexp_split_to_table(m.genre, '\s*[,/|;]\s*') AS part
+			WHERE NOT EXISTS (SELECT 1 FROM movie_genres mg WHERE mg.movie_id = m.id)
+		) p
+		WHERE slug <> ''
+		GROUP BY slug
+		ON CONFLICT (slug) DO NOTHING;`,
+	`INSERT INTO movie_genres (movie_id, genre_id)
+		SELECT DISTINCT m.id, g.id
+		FROM movies m
+			CROSS JOIN regexp_split_to_table(m.genre, '\s*[,/|;]\s*') AS part
+			JOIN genres g ON g.slug = left(trim(both '-' from regexp_replace(lower(trim(part)), '[^a-z0-9]+', '-', 'g')), 60)
+		WHERE NOT EXISTS (SELECT 1 FROM movie_genres mg WHERE mg.movie_id = m.id)
+		ON CONFLICT DO NOTHING;`,
+	// Teks genre disamakan dengan relasinya, misal "action, comedy" menjadi "Action, Comedy"
+	`UPDATE movies m SET genre = sub.names
+		FROM (
+			SELECT mg.movie_id, string_agg(g.name, ', ' ORDER BY g.name) AS names
+			FROM movie_genres mg JOIN genres g ON g.id = mg.genre_id
+			GROUP BY mg.movie_id
+		) sub
+		WHERE m.id = sub.movie_id AND m.genre IS DISTINCT FROM sub.names;`,
+}
+
+// phoneBackfill mengubah nomor lokal 0xx lama menjadi +<kode negara>xx. Kode negara di-bind dari
+// PHONE_DEFAULT_COUNTRY_CODE, sama seperti entities.NormalizePhoneNumber untuk nomor baru.
+const phoneBackfill = `UPDATE users SET phone_number = '+' || ?::text || substring(regexp_replace(phone_number, '[^0-9]', '', 'g') from 2)
+	WHERE phone_number !~ '^\+' AND regexp_replace(phone_number, '[^0-9]', '', 'g') ~ '^0[1-9][0-9]{6,13}$';`
+
+// Migrate menjalankan seluruh migrasi skema database
+func Migrate(db *gorm.DB) error {
+	for _, stmt := range preMigrations {
+		if err := db.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("pre migration failed: %w", err)
+		}
+	}
+
+	err := db.AutoMigrate(
+		&user.User{},
+		&movie.Genre{},
+		&movie.Movies{},
+		&movie.Person{},
+		&movie.MovieCredit{},
+		&studio.Studio{},
+		&studio.StudioSeat{},
+		&schedule.Schedules{},
+		&reservation.Reservation{},
+		&reservation.ReservationSeat{},
+		&payment.Payment{},
+		&payment.PaymentEvent{},
+	)
+	if err != nil {
+		return fmt.Errorf("auto migrate failed: %w", err)
+	}
+
+	for _, stmt := range postMigrations {
+		if err := db.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("post migration failed: %w", err)
+		}
+	}
+
+	if err := db.Exec(phoneBackfill, config.GetOrDefault("PHONE_DEFAULT_COUNTRY_CODE", "62")).Error; err != nil {
+		return fmt.Errorf("phone number backfill failed: %w", err)
+	}
+
+	return nil
+}
@@ -7,8 +7,27 @@ var (
 	ErrInvalidInput      = errors.New("invalid input data")
 	ErrDatabaseError     = errors.New("database operation failed")
 	ErrInvalidEmail      = errors.New("invalid email format")
+	ErrInvalidPhone      = errors.New("invalid phone number")
 	ErrEmailNotFound     = errors.New("user with this email not found!")
 	ErrWrongPassword     = errors.New("wrong password")
 	ErrFailedSession     = errors.New("failed to store session in redis")
 	ErrFailedCreateToken = errors.New("failed to create token")
+	ErrUnauthorizedUser  = errors.New("forbidden user")
+	ErrUserNotFound      = errors.New("user not found")
+	ErrInvalidRole       = errors.New("invalid role")
+	ErrSelfRoleChange    = errors.New("cannot change your own role")
+	ErrSelfAction        = errors.New("cannot perform this action on your own account")
+	ErrAccountSuspended  = errors.New("account is suspended")
+	ErrInvalidToken      = errors.New("invalid or expired token")
+	ErrSessionNotFound   = errors.New("session not found")
+	ErrAlreadyVerified   = errors.New("email already verified")
+
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrAccountLocked      = errors.New("too many failed login attempts, account temporarily locked")
+	ErrTooManyAttempts    = errors.New("too many failed login attempts from this address")
+
+	ErrInvalidTwoFactorCode = errors.New("invalid two-factor code")
+	ErrTwoFactorEnabled     = errors.New("two-factor authentication already enabled")
+	ErrTwoFactorNotEnabled  = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorRequired    = errors.New("two-factor authentication is required for admin accounts")
 )
@@ -6,17 +6,81 @@ type RegisterRequest struct {
 	Email       string `json:"email" validate:"required"`
 	Password    string `json:"password" validate:"required,min=6,max=100"`
 	FullName    string `json:"full_name" validate:"required,min=1,max=100"`
-	PhoneNumber string `json:"phone_number" validate:"required,min=1,max=13"`
-	Role        string `json:"role" validate:"required"`
+	PhoneNumber string `json:"phone_number" validate:"required,min=1,max=20"`
+}
+
+type UserListQuery struct {
+	Page   int    `form:"page"`
+	Limit  int    `form:"limit"`
+	Search string `form:"q"`
+	Role   string `form:"role" binding:"omitempty,oneof=user admin"`
+	Status string `form:"status" binding:"omitempty,oneof=active suspended"`
+}
+
+type UpdateRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=user admin"`
 }
 
 type LoginRequest struct {
-	Email    string `json:"email" validate:"required"`
-	Password string `json:"password" validate:"required,min=6,max=100"`
+	Email      string `json:"email" validate:"required"`
+	Password   string `json:"password" validate:"required,min=6,max=100"`
+	DeviceName string `json:"device_name,omitempty"` // opsional, ditampilkan di daftar sesi
+}
+
+type UpdateProfileRequest struct {
+	FullName    *string `json:"full_name" binding:"omitempty,min=1,max=100"`
+	PhoneNumber *string `json:"phone_number" binding:"omitempty"`
+}
+
+type ChangeEmailRequest struct {
+	NewEmail string `json:"new_email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=6,max=100"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=6,max=100"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type DisableTwoFactorRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"` // kode TOTP atau recovery code
+}
+
+type LoginTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"` // kode TOTP atau recovery code
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
 type UserSession struct {
-	ID    uuid.UUID `json:"id"`
-	Role  string    `json:"role"`
-	Email string    `json:"email"`
+	ID        uuid.UUID `json:"id"`
+	Role      string    `json:"role"`
+	Email     string    `json:"email"`
+	FamilyID  string    `json:"family_id,omitempty"`
+	TwoFactor bool      `json:"two_factor,omitempty"`
 }
@@ -7,16 +7,63 @@ import (
 )
 
 type UserResponse struct {
-	Message string `json:"message"`
-	Token   string `json:"token"`
+	Message      string `json:"message"`
+	Token        string `json:"token,omitempty"` // access token, kirim sebagai Bearer
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int64  `json:"expires_in,omitempty"` // umur access token dalam detik
+	// Diisi jika akun memakai 2FA: token baru didapat dari POST /login/2fa dengan challenge_token ini
+	TwoFactorRequired bool   `json:"two_factor_required,omitempty"`
+	ChallengeToken    string `json:"challenge_token,omitempty"`
+}
+
+type TwoFactorEnrollResponse struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauth_uri"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
 }
 
 type RegisterResponse struct {
-	ID          uuid.UUID `json:"id"`
-	Email       string    `json:"email"`
-	FullName    string    `json:"full_name"`
-	PhoneNumber string    `json:"phone_number"`
-	Role        string    `json:"role"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID               uuid.UUID  `json:"id"`
+	Email            string     `json:"email"`
+	FullName         string     `json:"full_name"`
+	PhoneNumber      string     `json:"phone_number"`
+	Role             string     `json:"role"`
+	EmailVerified    bool       `json:"email_verified"`
+	TwoFactorEnabled bool       `json:"two_factor_enabled"`
+	SuspendedAt      *time.Time `json:"suspended_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	Device     string    `json:"device"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	Current    bool      `json:"current"`
+}
+
+type UserListResponse struct {
+	Data       []RegisterResponse `json:"data"`
+	Page       int                `json:"page"`
+	Limit      int                `json:"limit"`
+	Total      int64              `json:"total"`
+	TotalPages int                `json:"total_pages"`
+}
+
+type ReservationSummary struct {
+	Total    int64            `json:"total"`
+	ByStatus map[string]int64 `json:"by_status"`
+	// NetSpent adalah total pembayaran yang berhasil dikurangi refund
+	NetSpent int64 `json:"net_spent"`
+}
+
+type AdminUserDetailResponse struct {
+	RegisterResponse
+	Reservations ReservationSummary `json:"reservations"`
 }
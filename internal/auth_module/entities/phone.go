@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"fmt"
+	"movie-ticket/config"
+	customerror "movie-ticket/internal/auth_module/custom_error"
+	"regexp"
+	"strings"
+)
+
+// E.164: tanda +, kode negara tanpa 0 di depan, maksimal 15 digit
+var e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+
+// NormalizePhoneNumber mengubah nomor telepon ke format E.164.
+// Nomor lokal berawalan 0 dianggap memakai kode negara PHONE_DEFAULT_COUNTRY_CODE (default 62).
+func NormalizePhoneNumber(raw string) (string, error) {
+	phone := strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "").Replace(strings.TrimSpace(raw))
+
+	switch {
+	case strings.HasPrefix(phone, "+"):
+	case strings.HasPrefix(phone, "00"):
+		phone = "+" + phone[2:]
+	case strings.HasPrefix(phone, "0"):
+		phone = "+" + config.GetOrDefault("PHONE_DEFAULT_COUNTRY_CODE", "62") + phone[1:]
+	default:
+		phone = "+" + phone
+	}
+
+	if !e164Pattern.MatchString(phone) {
+		return "", fmt.Errorf("%w: %s, use E.164 format (e.g. +6281234567890)", customerror.ErrInvalidPhone, raw)
+	}
+
+	return phone, nil
+}
@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"errors"
+	customerror "movie-ticket/internal/auth_module/custom_error"
+	"testing"
+)
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	t.Setenv("PHONE_DEFAULT_COUNTRY_CODE", "")
+
+	tests := []struct {
+		name    string
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{"sudah E.164", "+6281234567890", "+6281234567890", false},
+		{"nomor lokal", "081234567890", "+6281234567890", false},
+		{"dengan spasi dan tanda hubung", " 0812-3456 7890 ", "+6281234567890", false},
+		{"dengan kurung dan titik", "+1 (415) 555.2671", "+14155552671", false},
+		{"awalan 00", "0044 20 7946 0958", "+442079460958", false},
+		{"tanpa plus", "6281234567890", "+6281234567890", false},
+		{"terlalu pendek", "+62812", "", true},
+		{"terlalu panjang", "+1234567890123456", "", true},
+		{"kode negara 0", "+0812345678", "", true},
+		{"huruf", "08123abc890", "", true},
+		{"kosong", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizePhoneNumber(tt.raw)
+			if tt.wantErr {
+				if !errors.Is(err, customerror.ErrInvalidPhone) {
+					t.Fatalf("NormalizePhoneNumber(%q) error = %v, want ErrInvalidPhone", tt.raw, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizePhoneNumber(%q) unexpected error: %v", tt.raw, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizePhoneNumber(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizePhoneNumberDefaultCountry(t *testing.T) {
+	t.Setenv("PHONE_DEFAULT_COUNTRY_CODE", "65")
+
+	got, err := NormalizePhoneNumber("091234567")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "+6591234567" {
+		t.Errorf("got %q, want +6591234567", got)
+	}
+}
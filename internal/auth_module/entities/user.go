@@ -4,6 +4,12 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
 )
 
 type User struct {
@@ -13,6 +19,16 @@ type User struct {
 	FullName    string    `gorm:"type:varchar(100)" json:"full_name" binding:"required"`
 	PhoneNumber string    `json:"phone_number" binding:"required"`
 	Role        string    `gorm:"type:varchar(5)" json:"role"`
-	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	// EmailVerifiedAt nil berarti email belum diverifikasi
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	// TOTPSecret (base32) terisi sejak enroll, 2FA baru aktif setelah TOTPEnabledAt terisi
+	TOTPSecret    string     `gorm:"column:totp_secret;type:varchar(64)" json:"-"`
+	TOTPEnabledAt *time.Time `gorm:"column:totp_enabled_at" json:"totp_enabled_at"`
+	// RecoveryCodes berisi hash SHA-256 recovery code yang belum dipakai, dipisah koma
+	RecoveryCodes string `gorm:"type:text" json:"-"`
+	// SuspendedAt terisi selama akun disuspend admin, akun tidak bisa login
+	SuspendedAt *time.Time     `json:"suspended_at"`
+	CreatedAt   time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
 }
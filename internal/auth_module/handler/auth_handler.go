@@ -2,13 +2,16 @@ package handler
 
 import (
 	"errors"
+	"movie-ticket/config"
 	customerror "movie-ticket/internal/auth_module/custom_error"
 	"movie-ticket/internal/auth_module/dto"
 	"movie-ticket/internal/auth_module/services"
+	"movie-ticket/internal/middleware"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type AuthHandler struct {
@@ -19,21 +22,52 @@ func NewAuthHandler(r *gin.RouterGroup, svc services.AuthService) {
 	h := AuthHandler{svc: svc}
 	r.POST("/register", h.Register)
 	r.POST("/login", h.Login)
+	r.POST("/login/2fa", h.LoginTwoFactor)
 	r.POST("/logout", h.Logout)
 	r.POST("/refresh", h.RefreshToken)
+	r.POST("/password/forgot", h.ForgotPassword)
+	r.POST("/password/reset", h.ResetPassword)
+	r.POST("/email/verify", h.VerifyEmail)
+	r.POST("/me/email/confirm", h.ConfirmEmailChange)
+}
+
+// NewAuthHandlerSession mendaftarkan endpoint yang butuh login (dipasang di group dengan JwtMiddleware)
+func NewAuthHandlerSession(r *gin.RouterGroup, svc services.AuthService) {
+	h := AuthHandler{svc: svc}
+	r.GET("/me", h.GetProfile)
+	r.PATCH("/me", h.UpdateProfile)
+	r.POST("/me/email", h.RequestEmailChange)
+	r.GET("/sessions", h.ListSessions)
+	r.DELETE("/sessions/:id", h.RevokeSession)
+	r.POST("/logout-all", h.LogoutAll)
+	r.POST("/change-password", h.ChangePassword)
+	r.POST("/email/verify/resend", h.ResendVerification)
+	r.POST("/2fa/enroll", h.EnrollTwoFactor)
+	r.POST("/2fa/enable", h.EnableTwoFactor)
+	r.POST("/2fa/disable", h.DisableTwoFactor)
+}
+
+func NewAuthHandlerAdmin(r *gin.RouterGroup, svc services.AuthService) {
+	h := AuthHandler{svc: svc}
+	r.GET("/users", h.ListUsers)
+	r.GET("/users/:id", h.GetUserDetail)
+	r.PATCH("/users/:id/role", h.UpdateRole)
+	r.POST("/users/:id/suspend", h.SuspendUser)
+	r.POST("/users/:id/unsuspend", h.UnsuspendUser)
+	r.DELETE("/users/:id", h.DeleteUser)
+	r.POST("/users/:id/unlock", h.UnlockUser)
 }
 
 // Register godoc
 // @Summary Register user baru
-// @Description Membuat akun baru dengan email & password
+// @Description Membuat akun baru dengan email & password. Akun baru selalu ber-role user dan link verifikasi dikirim ke email; akun belum bisa memesan tiket sebelum email diverifikasi
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Param request body dto.RegisterRequest true "Register Data"
 // @Success 201 {object} map[string]interface{} "Account created successfully"
-// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid input"
-// @Failure 302 {object} map[string]interface{} "Found - Email sudah ada"
-// @Failure 404 {object} map[string]interface{} "Not Found - Email not found"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid input, format email atau nomor telepon (E.164) salah"
+// @Failure 409 {object} map[string]interface{} "Conflict - Email sudah terdaftar"
 // @Failure 500 {object} map[string]interface{} "Internal Server Error"
 // @Router /register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
@@ -46,12 +80,10 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	user, err := h.svc.Register(&input)
 	if err != nil {
 		switch {
-		case errors.Is(err, customerror.ErrInvalidInput):
+		case errors.Is(err, customerror.ErrInvalidInput), errors.Is(err, customerror.ErrInvalidEmail), errors.Is(err, customerror.ErrInvalidPhone):
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		case errors.Is(err, customerror.ErrEmailExist):
-			c.JSON(http.StatusFound, gin.H{"error": err.Error()})
-		case errors.Is(err, customerror.ErrEmailNotFound):
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
@@ -66,15 +98,16 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 // Login godoc
 // @Summary Login user
-// @Description Masuk akun dengan email & password untuk mendapatkan access token
+// @Description Masuk akun dengan email & password untuk mendapatkan access token berumur pendek dan refresh token. Jika 2FA aktif, response berisi two_factor_required dan challenge_token yang harus ditukar di /login/2fa
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Param request body dto.LoginRequest true "Login Data"
 // @Success 200 {object} map[string]interface{} "Login berhasil dengan token"
 // @Failure 400 {object} map[string]interface{} "Bad Request - Invalid input atau session gagal"
-// @Failure 401 {object} map[string]interface{} "Unauthorized - Password salah"
-// @Failure 404 {object} map[string]interface{} "Not Found - Email tidak ditemukan"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - Email atau password salah"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Akun disuspend"
+// @Failure 429 {object} map[string]interface{} "Too Many Requests - Akun atau IP sementara dikunci karena terlalu banyak login gagal"
 // @Failure 500 {object} map[string]interface{} "Internal Server Error"
 // @Router /login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
@@ -84,13 +117,15 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	users, err := h.svc.Login(&req)
+	users, err := h.svc.Login(&req, clientInfo(c))
 	if err != nil {
 		switch {
-		case errors.Is(err, customerror.ErrEmailNotFound):
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		case errors.Is(err, customerror.ErrWrongPassword):
+		case errors.Is(err, customerror.ErrInvalidCredentials):
 			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		case errors.Is(err, customerror.ErrAccountLocked), errors.Is(err, customerror.ErrTooManyAttempts):
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		case errors.Is(err, customerror.ErrAccountSuspended):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		case errors.Is(err, customerror.ErrFailedSession):
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		default:
@@ -104,7 +139,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 // Logout godoc
 // @Summary Logout user
-// @Description Keluar dari akun dan invalidate token yang aktif
+// @Description Keluar dari akun, invalidate access token yang aktif beserta refresh token dari login yang sama
 // @Tags Auth
 // @Accept json
 // @Produce json
@@ -134,31 +169,948 @@ func (h *AuthHandler) Logout(ctx *gin.Context) {
 
 // RefreshToken godoc
 // @Summary Refresh access token
-// @Description Mendapatkan access token baru menggunakan token yang masih valid
+// @Description Menukar refresh token dengan access token dan refresh token baru. Setiap refresh token hanya bisa dipakai sekali; refresh token yang dipakai ulang akan me-revoke seluruh sesi login tersebut
 // @Tags Auth
 // @Accept json
 // @Produce json
-// @Param Authorization header string true "Bearer token" default(Bearer <token>)
-// @Success 200 {object} map[string]interface{} "Token refreshed successfully"
-// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid authorization header"
-// @Failure 401 {object} map[string]interface{} "Unauthorized - Token expired atau invalid"
+// @Param request body dto.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} dto.UserResponse "Token refreshed successfully"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Refresh token wajib diisi"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - Refresh token invalid, expired, atau dipakai ulang"
 // @Failure 500 {object} map[string]interface{} "Internal Server Error"
 // @Router /refresh [post]
-// @Security BearerAuth
 func (h *AuthHandler) RefreshToken(ctx *gin.Context) {
-	authHeader := ctx.GetHeader("Authorization")
-	if !strings.HasPrefix(authHeader, "Bearer ") {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid authorization header"})
+	var req dto.RefreshTokenRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	oldToken := strings.TrimPrefix(authHeader, "Bearer ")
-
-	response, err := h.svc.RefreshToken(oldToken)
+	response, err := h.svc.RefreshToken(req.RefreshToken, clientInfo(ctx))
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		switch {
+		case errors.Is(err, customerror.ErrInvalidToken):
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
 	ctx.JSON(http.StatusOK, response)
 }
+
+// ForgotPassword godoc
+// @Summary Minta token reset password
+// @Description Mengirim link/token reset password ke email. Response selalu sama walaupun email tidak terdaftar. Token berlaku singkat dan hanya bisa dipakai sekali; permintaan baru membatalkan token sebelumnya
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ForgotPasswordRequest true "Email akun"
+// @Success 200 {object} map[string]interface{} "Reset instructions sent"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid input"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /password/forgot [post]
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req dto.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.ForgotPassword(&req); err != nil {
+		switch {
+		case errors.Is(err, customerror.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "If the email is registered, reset instructions have been sent"})
+}
+
+// ResetPassword godoc
+// @Summary Reset password dengan token
+// @Description Mengganti password memakai token dari email reset. Token langsung hangus setelah dipakai dan semua sesi login user di-revoke
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ResetPasswordRequest true "Token reset dan password baru"
+// @Success 200 {object} map[string]interface{} "Password has been reset"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid input atau token invalid/expired"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /password/reset [post]
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.ResetPassword(&req); err != nil {
+		switch {
+		case errors.Is(err, customerror.ErrInvalidInput), errors.Is(err, customerror.ErrInvalidToken), errors.Is(err, customerror.ErrUserNotFound):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password has been reset, please login again"})
+}
+
+// LoginTwoFactor godoc
+// @Summary Login langkah kedua (2FA)
+// @Description Menukar challenge_token dari /login dan kode TOTP (atau recovery code) dengan access token dan refresh token. Challenge berlaku 5 menit dan hangus setelah 5 kode salah. Kode salah ikut dihitung sebagai login gagal akun, dan hanya 3 challenge terakhir per user yang berlaku
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body dto.LoginTwoFactorRequest true "Challenge token dan kode"
+// @Success 200 {object} dto.UserResponse "Success login"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - Challenge invalid/expired atau kode salah"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Akun disuspend"
+// @Failure 429 {object} map[string]interface{} "Too Many Requests - Akun atau IP sementara dikunci karena terlalu banyak login gagal"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /login/2fa [post]
+func (h *AuthHandler) LoginTwoFactor(c *gin.Context) {
+	var req dto.LoginTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.svc.LoginTwoFactor(&req, clientInfo(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, customerror.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, customerror.ErrInvalidToken), errors.Is(err, customerror.ErrInvalidTwoFactorCode), errors.Is(err, customerror.ErrUserNotFound):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		case errors.Is(err, customerror.ErrAccountLocked), errors.Is(err, customerror.ErrTooManyAttempts):
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		case errors.Is(err, customerror.ErrAccountSuspended):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// EnrollTwoFactor godoc
+// @Summary Mulai pendaftaran 2FA (TOTP)
+// @Description Membuat secret TOTP baru dan otpauth URI untuk di-scan aplikasi authenticator. 2FA belum aktif sampai dikonfirmasi di /2fa/enable
+// @Tags Auth
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Success 200 {object} dto.TwoFactorEnrollResponse "Secret dan otpauth URI"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 409 {object} map[string]interface{} "Conflict - 2FA sudah aktif"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /2fa/enroll [post]
+// @Security BearerAuth
+func (h *AuthHandler) EnrollTwoFactor(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.svc.EnrollTwoFactor(userID)
+	if err != nil {
+		h.handleTwoFactorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// EnableTwoFactor godoc
+// @Summary Aktifkan 2FA
+// @Description Mengaktifkan 2FA dengan kode pertama dari authenticator dan mengembalikan recovery code (hanya ditampilkan sekali). Login ulang diperlukan agar sesi tercatat sebagai sesi 2FA
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param request body dto.TwoFactorCodeRequest true "Kode TOTP"
+// @Success 200 {object} dto.RecoveryCodesResponse "Recovery codes"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Kode salah atau belum enroll"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 409 {object} map[string]interface{} "Conflict - 2FA sudah aktif"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /2fa/enable [post]
+// @Security BearerAuth
+func (h *AuthHandler) EnableTwoFactor(c *gin.Context) {
+	var req dto.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.svc.EnableTwoFactor(userID, &req)
+	if err != nil {
+		h.handleTwoFactorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DisableTwoFactor godoc
+// @Summary Nonaktifkan 2FA
+// @Description Mematikan 2FA dengan password dan kode TOTP atau recovery code. Ditolak untuk admin jika 2FA diwajibkan (ADMIN_REQUIRE_2FA)
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param request body dto.DisableTwoFactorRequest true "Password dan kode"
+// @Success 200 {object} map[string]interface{} "Two-factor authentication disabled"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Kode salah atau 2FA belum aktif"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - Password salah"
+// @Failure 403 {object} map[string]interface{} "Forbidden - 2FA wajib untuk admin"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /2fa/disable [post]
+// @Security BearerAuth
+func (h *AuthHandler) DisableTwoFactor(c *gin.Context) {
+	var req dto.DisableTwoFactorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	role, err := middleware.GetUserRoleFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.DisableTwoFactor(userID, role, &req); err != nil {
+		h.handleTwoFactorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled"})
+}
+
+func (h *AuthHandler) handleTwoFactorError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, customerror.ErrInvalidInput), errors.Is(err, customerror.ErrInvalidTwoFactorCode), errors.Is(err, customerror.ErrTwoFactorNotEnabled):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, customerror.ErrWrongPassword):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, customerror.ErrTwoFactorRequired):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, customerror.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, customerror.ErrTwoFactorEnabled):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// VerifyEmail godoc
+// @Summary Verifikasi email
+// @Description Memverifikasi email memakai token dari link yang dikirim saat register atau resend. Token berlaku terbatas dan batal jika email diganti
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body dto.VerifyEmailRequest true "Token verifikasi"
+// @Success 200 {object} map[string]interface{} "Email verified"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Token invalid atau expired"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /email/verify [post]
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req dto.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.svc.VerifyEmail(&req)
+	if err != nil {
+		switch {
+		case errors.Is(err, customerror.ErrInvalidInput), errors.Is(err, customerror.ErrInvalidToken):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Email verified",
+		"data":    user,
+	})
+}
+
+// ResendVerification godoc
+// @Summary Kirim ulang email verifikasi
+// @Description Mengirim ulang link verifikasi ke email user yang sedang login. Link lama tetap berlaku sampai expired
+// @Tags Auth
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Success 200 {object} map[string]interface{} "Verification email sent"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found - User tidak ditemukan"
+// @Failure 409 {object} map[string]interface{} "Conflict - Email sudah terverifikasi"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /email/verify/resend [post]
+// @Security BearerAuth
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.ResendVerification(userID); err != nil {
+		switch {
+		case errors.Is(err, customerror.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, customerror.ErrAlreadyVerified):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Verification email sent"})
+}
+
+// GetProfile godoc
+// @Summary Profil user yang sedang login
+// @Tags Profile
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Success 200 {object} dto.RegisterResponse "Profil user"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found - User tidak ditemukan"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /me [get]
+// @Security BearerAuth
+func (h *AuthHandler) GetProfile(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.svc.GetProfile(userID)
+	if err != nil {
+		h.handleProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": user})
+}
+
+// UpdateProfile godoc
+// @Summary Ubah profil
+// @Description Mengubah nama dan/atau nomor telepon. Field yang tidak dikirim tidak diubah. Nomor telepon disimpan dalam format E.164, nomor lokal berawalan 0 dianggap nomor Indonesia
+// @Tags Profile
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param request body dto.UpdateProfileRequest true "Data profil"
+// @Success 200 {object} dto.RegisterResponse "Profile updated"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid input atau nomor telepon tidak valid"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found - User tidak ditemukan"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /me [patch]
+// @Security BearerAuth
+func (h *AuthHandler) UpdateProfile(c *gin.Context) {
+	var req dto.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.svc.UpdateProfile(userID, &req)
+	if err != nil {
+		h.handleProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Profile updated",
+		"data":    user,
+	})
+}
+
+// RequestEmailChange godoc
+// @Summary Minta ganti email
+// @Description Mengirim link konfirmasi ke email baru. Email akun baru berubah setelah link dikonfirmasi di /me/email/confirm
+// @Tags Profile
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param request body dto.ChangeEmailRequest true "Email baru dan password saat ini"
+// @Success 200 {object} map[string]interface{} "Confirmation email sent"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid input atau format email salah"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - Password salah"
+// @Failure 409 {object} map[string]interface{} "Conflict - Email sudah dipakai"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /me/email [post]
+// @Security BearerAuth
+func (h *AuthHandler) RequestEmailChange(c *gin.Context) {
+	var req dto.ChangeEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.RequestEmailChange(userID, &req); err != nil {
+		h.handleProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Confirmation email sent to the new address"})
+}
+
+// ConfirmEmailChange godoc
+// @Summary Konfirmasi ganti email
+// @Description Mengganti email akun memakai token dari email konfirmasi. Email baru langsung terverifikasi, email lama menerima pemberitahuan, dan semua sesi login di-revoke
+// @Tags Profile
+// @Accept json
+// @Produce json
+// @Param request body dto.ConfirmEmailChangeRequest true "Token konfirmasi"
+// @Success 200 {object} dto.RegisterResponse "Email changed"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Token invalid atau expired"
+// @Failure 409 {object} map[string]interface{} "Conflict - Email sudah dipakai"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /me/email/confirm [post]
+func (h *AuthHandler) ConfirmEmailChange(c *gin.Context) {
+	var req dto.ConfirmEmailChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.svc.ConfirmEmailChange(&req)
+	if err != nil {
+		h.handleProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Email changed, please login again",
+		"data":    user,
+	})
+}
+
+func (h *AuthHandler) handleProfileError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, customerror.ErrInvalidInput), errors.Is(err, customerror.ErrInvalidEmail),
+		errors.Is(err, customerror.ErrInvalidPhone), errors.Is(err, customerror.ErrInvalidToken):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, customerror.ErrWrongPassword):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, customerror.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, customerror.ErrEmailExist):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// ListSessions godoc
+// @Summary Daftar sesi login aktif
+// @Description Menampilkan semua sesi login aktif milik user (perangkat, user agent, IP, waktu login dan terakhir dipakai). Sesi yang sedang dipakai ditandai current
+// @Tags Auth
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Success 200 {object} map[string]interface{} "Daftar sesi"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /sessions [get]
+// @Security BearerAuth
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	sessionID, err := middleware.GetSessionIDFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	sessions, err := h.svc.ListSessions(userID, sessionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": sessions})
+}
+
+// RevokeSession godoc
+// @Summary Revoke satu sesi login
+// @Description Logout dari satu perangkat. Access token dan refresh token sesi tersebut langsung tidak berlaku
+// @Tags Auth
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Session ID"
+// @Success 200 {object} map[string]interface{} "Session revoked"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not Found - Sesi tidak ditemukan"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /sessions/{id} [delete]
+// @Security BearerAuth
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.RevokeSession(userID, c.Param("id")); err != nil {
+		switch {
+		case errors.Is(err, customerror.ErrSessionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Session revoked"})
+}
+
+// LogoutAll godoc
+// @Summary Logout dari semua perangkat
+// @Description Me-revoke semua sesi login milik user, termasuk sesi yang sedang dipakai
+// @Tags Auth
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Success 200 {object} map[string]interface{} "Logged out from all sessions"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /logout-all [post]
+// @Security BearerAuth
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.LogoutAll(userID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out from all sessions"})
+}
+
+// ChangePassword godoc
+// @Summary Ganti password
+// @Description Mengganti password user yang sedang login. Semua sesi login (termasuk sesi ini) akan di-revoke sehingga harus login ulang
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param request body dto.ChangePasswordRequest true "Password lama dan baru"
+// @Success 200 {object} map[string]interface{} "Password changed"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - Password lama salah"
+// @Failure 404 {object} map[string]interface{} "Not Found - User tidak ditemukan"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /change-password [post]
+// @Security BearerAuth
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	var req dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.ChangePassword(userID, &req); err != nil {
+		switch {
+		case errors.Is(err, customerror.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, customerror.ErrWrongPassword):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		case errors.Is(err, customerror.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password changed, please login again"})
+}
+
+// UpdateRole godoc
+// @Summary Ubah role user (admin)
+// @Description Mempromosikan user menjadi admin atau menurunkan admin menjadi user. Admin tidak dapat mengubah role dirinya sendiri. Semua sesi user dicabut, role baru berlaku setelah user login ulang
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "User ID" format(uuid)
+// @Param request body dto.UpdateRoleRequest true "Role baru (user/admin)"
+// @Success 200 {object} map[string]interface{} "Role updated successfully"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid input atau mengubah role sendiri"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Hanya admin"
+// @Failure 404 {object} map[string]interface{} "Not Found - User tidak ditemukan"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /admin/users/{id}/role [patch]
+// @Security BearerAuth
+func (h *AuthHandler) UpdateRole(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	var req dto.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	role, err := middleware.GetUserRoleFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	callerID, err := middleware.GetUserIDFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.svc.UpdateRole(role, callerID, userID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, customerror.ErrUnauthorizedUser):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, customerror.ErrInvalidInput), errors.Is(err, customerror.ErrInvalidRole), errors.Is(err, customerror.ErrSelfRoleChange):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, customerror.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Role updated successfully",
+		"data":    user,
+	})
+}
+
+// UnlockUser godoc
+// @Summary Buka kunci login user (admin)
+// @Description Menghapus kunci login dan hitungan login gagal sebuah akun sehingga user bisa langsung login lagi
+// @Tags Auth
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "User ID" format(uuid)
+// @Success 200 {object} map[string]interface{} "Account unlocked"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid user id"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Hanya admin"
+// @Failure 404 {object} map[string]interface{} "Not Found - User tidak ditemukan"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /admin/users/{id}/unlock [post]
+// @Security BearerAuth
+func (h *AuthHandler) UnlockUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	role, err := middleware.GetUserRoleFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.UnlockUser(role, userID); err != nil {
+		switch {
+		case errors.Is(err, customerror.ErrUnauthorizedUser):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, customerror.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Account unlocked"})
+}
+
+// ListUsers godoc
+// @Summary Daftar user (admin)
+// @Description Menampilkan daftar user dengan pagination. Bisa dicari berdasarkan email, nama, atau nomor telepon dan difilter berdasarkan role dan status. User yang sudah dihapus tidak ditampilkan
+// @Tags Auth
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param page query int false "Halaman (default 1)"
+// @Param limit query int false "Jumlah per halaman (default 10, maks 100)"
+// @Param q query string false "Cari email, nama, atau nomor telepon"
+// @Param role query string false "Filter role" Enums(user, admin)
+// @Param status query string false "Filter status" Enums(active, suspended)
+// @Success 200 {object} dto.UserListResponse
+// @Failure 400 {object} map[string]interface{} "Bad Request - Query tidak valid"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Hanya admin"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /admin/users [get]
+// @Security BearerAuth
+func (h *AuthHandler) ListUsers(c *gin.Context) {
+	var query dto.UserListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	role, err := middleware.GetUserRoleFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	users, err := h.svc.ListUsers(role, &query)
+	if err != nil {
+		handleUserAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
+
+// GetUserDetail godoc
+// @Summary Detail user (admin)
+// @Description Menampilkan profil user beserta jumlah reservasi per status dan total pembayaran bersih (setelah refund)
+// @Tags Auth
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "User ID" format(uuid)
+// @Success 200 {object} map[string]interface{} "User detail"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid user id"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Hanya admin"
+// @Failure 404 {object} map[string]interface{} "Not Found - User tidak ditemukan"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /admin/users/{id} [get]
+// @Security BearerAuth
+func (h *AuthHandler) GetUserDetail(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	role, err := middleware.GetUserRoleFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.svc.GetUserDetail(role, userID)
+	if err != nil {
+		handleUserAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": user})
+}
+
+// SuspendUser godoc
+// @Summary Suspend user (admin)
+// @Description Memblokir login user dan mencabut semua sesinya, access token yang sudah terbit langsung tidak berlaku. Admin tidak dapat men-suspend dirinya sendiri
+// @Tags Auth
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "User ID" format(uuid)
+// @Success 200 {object} map[string]interface{} "User suspended"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid user id atau akun sendiri"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Hanya admin"
+// @Failure 404 {object} map[string]interface{} "Not Found - User tidak ditemukan"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /admin/users/{id}/suspend [post]
+// @Security BearerAuth
+func (h *AuthHandler) SuspendUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	role, err := middleware.GetUserRoleFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	callerID, err := middleware.GetUserIDFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.svc.SuspendUser(role, callerID, userID)
+	if err != nil {
+		handleUserAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User suspended",
+		"data":    user,
+	})
+}
+
+// UnsuspendUser godoc
+// @Summary Batalkan suspend user (admin)
+// @Description Mengizinkan user yang disuspend untuk login kembali
+// @Tags Auth
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "User ID" format(uuid)
+// @Success 200 {object} map[string]interface{} "User unsuspended"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid user id"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Hanya admin"
+// @Failure 404 {object} map[string]interface{} "Not Found - User tidak ditemukan"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /admin/users/{id}/unsuspend [post]
+// @Security BearerAuth
+func (h *AuthHandler) UnsuspendUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	role, err := middleware.GetUserRoleFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.svc.UnsuspendUser(role, userID)
+	if err != nil {
+		handleUserAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User unsuspended",
+		"data":    user,
+	})
+}
+
+// DeleteUser godoc
+// @Summary Hapus user (admin)
+// @Description Soft delete user dan mencabut semua sesinya. Riwayat reservasi dan pembayaran tetap tersimpan, dan email user tersebut tidak dapat dipakai untuk mendaftar lagi. Admin tidak dapat menghapus dirinya sendiri
+// @Tags Auth
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "User ID" format(uuid)
+// @Success 200 {object} map[string]interface{} "User deleted"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid user id atau akun sendiri"
+// @Failure 403 {object} map[string]interface{} "Forbidden - Hanya admin"
+// @Failure 404 {object} map[string]interface{} "Not Found - User tidak ditemukan"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /admin/users/{id} [delete]
+// @Security BearerAuth
+func (h *AuthHandler) DeleteUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	role, err := middleware.GetUserRoleFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	callerID, err := middleware.GetUserIDFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.svc.DeleteUser(role, callerID, userID); err != nil {
+		handleUserAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User deleted"})
+}
+
+func handleUserAdminError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, customerror.ErrUnauthorizedUser):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, customerror.ErrInvalidInput), errors.Is(err, customerror.ErrSelfAction):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, customerror.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// clientInfo memakai IP dari X-Forwarded-For hanya jika TRUSTED_PROXIES diisi, supaya
+// lockout per IP tidak bisa dihindari dengan memalsukan header
+func clientInfo(c *gin.Context) middleware.ClientInfo {
+	ip := c.RemoteIP()
+	if len(config.GetList("TRUSTED_PROXIES")) > 0 {
+		ip = c.ClientIP()
+	}
+
+	return middleware.ClientInfo{
+		UserAgent: c.Request.UserAgent(),
+		IP:        ip,
+	}
+}
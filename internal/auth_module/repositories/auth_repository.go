@@ -4,13 +4,46 @@ import (
 	"errors"
 	"movie-ticket/infra/postgres"
 	"movie-ticket/internal/auth_module/entities"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type AuthRepository interface {
 	Create(user *entities.User) error
 	FindByEmail(email string) (*entities.User, error)
+	// EmailTaken juga menghitung akun yang sudah dihapus, email tersebut tidak bisa dipakai lagi
+	EmailTaken(email string) (bool, error)
+	FindByID(id uuid.UUID) (*entities.User, error)
+	UpdateRole(id uuid.UUID, role string) error
+	UpdatePassword(id uuid.UUID, hash string) error
+	UpdateProfile(id uuid.UUID, fullName, phoneNumber string) error
+	UpdateEmail(id uuid.UUID, email string, verifiedAt time.Time) error
+	MarkEmailVerified(id uuid.UUID, at time.Time) error
+	UpdateTwoFactor(id uuid.UUID, secret string, enabledAt *time.Time, recoveryCodes string) error
+	// ConsumeRecoveryCode bernilai false jika recovery code tidak ada atau sudah dipakai request lain
+	ConsumeRecoveryCode(id uuid.UUID, codeHash string) (bool, error)
+	CountByRole(role string) (int64, error)
+	List(filter UserFilter) ([]entities.User, int64, error)
+	SetSuspended(id uuid.UUID, at *time.Time) error
+	SoftDelete(id uuid.UUID) error
+	ReservationStats(id uuid.UUID) (*ReservationStats, error)
+}
+
+// UserFilter untuk daftar user di admin, field kosong berarti tidak difilter
+type UserFilter struct {
+	Search string // dicocokkan ke email, nama, dan nomor telepon
+	Role   string
+	Status string // active atau suspended
+	Offset int
+	Limit  int
+}
+
+type ReservationStats struct {
+	ByStatus map[string]int64
+	NetSpent int64
 }
 
 type authRepo struct{}
@@ -26,7 +59,7 @@ func (r *authRepo) Create(user *entities.User) error {
 func (r *authRepo) FindByEmail(email string) (*entities.User, error) {
 	var user entities.User
 
-	err := postgres.DB.Where("email = ?", email).First(&user).Error
+	err := postgres.DB.Where("lower(email) = lower(?)", email).First(&user).Error
 
 	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
@@ -34,3 +67,154 @@ func (r *authRepo) FindByEmail(email string) (*entities.User, error) {
 
 	return &user, err
 }
+
+func (r *authRepo) EmailTaken(email string) (bool, error) {
+	var count int64
+	err := postgres.DB.Unscoped().Model(&entities.User{}).Where("lower(email) = lower(?)", email).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *authRepo) FindByID(id uuid.UUID) (*entities.User, error) {
+	var user entities.User
+
+	err := postgres.DB.Where("id = ?", id).First(&user).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (r *authRepo) UpdateRole(id uuid.UUID, role string) error {
+	return postgres.DB.Model(&entities.User{}).Where("id = ?", id).Update("role", role).Error
+}
+
+func (r *authRepo) UpdatePassword(id uuid.UUID, hash string) error {
+	return postgres.DB.Model(&entities.User{}).Where("id = ?", id).Update("password", hash).Error
+}
+
+func (r *authRepo) UpdateProfile(id uuid.UUID, fullName, phoneNumber string) error {
+	return postgres.DB.Model(&entities.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"full_name":    fullName,
+		"phone_number": phoneNumber,
+	}).Error
+}
+
+func (r *authRepo) UpdateEmail(id uuid.UUID, email string, verifiedAt time.Time) error {
+	return postgres.DB.Model(&entities.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"email":             email,
+		"email_verified_at": verifiedAt,
+	}).Error
+}
+
+func (r *authRepo) MarkEmailVerified(id uuid.UUID, at time.Time) error {
+	return postgres.DB.Model(&entities.User{}).
+		Where("id = ? AND email_verified_at IS NULL", id).
+		Update("email_verified_at", at).Error
+}
+
+// UpdateTwoFactor menimpa seluruh kolom 2FA sekaligus, nilai kosong/nil ikut disimpan
+func (r *authRepo) UpdateTwoFactor(id uuid.UUID, secret string, enabledAt *time.Time, recoveryCodes string) error {
+	return postgres.DB.Model(&entities.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"totp_secret":     secret,
+		"totp_enabled_at": enabledAt,
+		"recovery_codes":  recoveryCodes,
+	}).Error
+}
+
+// ConsumeRecoveryCode menghapus satu hash dari recovery_codes dalam satu UPDATE bersyarat,
+// sehingga recovery code yang sama tidak bisa dipakai dua request sekaligus
+func (r *authRepo) ConsumeRecoveryCode(id uuid.UUID, codeHash string) (bool, error) {
+	result := postgres.DB.Model(&entities.User{}).
+		Where("id = ? AND ? = ANY(string_to_array(recovery_codes, ','))", id, codeHash).
+		Update("recovery_codes", gorm.Expr("array_to_string(array_remove(string_to_array(recovery_codes, ','), ?), ',')", codeHash))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *authRepo) CountByRole(role string) (int64, error) {
+	var count int64
+	err := postgres.DB.Model(&entities.User{}).Where("role = ?", role).Count(&count).Error
+	return count, err
+}
+
+func (r *authRepo) List(filter UserFilter) ([]entities.User, int64, error) {
+	query := postgres.DB.Model(&entities.User{})
+
+	if filter.Search != "" {
+		like := "%" + escapeLike(filter.Search) + "%"
+		query = query.Where(`email ILIKE ? ESCAPE '\' OR full_name ILIKE ? ESCAPE '\' OR phone_number ILIKE ? ESCAPE '\'`, like, like, like)
+	}
+
+	if filter.Role != "" {
+		query = query.Where("role = ?", filter.Role)
+	}
+
+	switch filter.Status {
+	case "active":
+		query = query.Where("suspended_at IS NULL")
+	case "suspended":
+		query = query.Where("suspended_at IS NOT NULL")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []entities.User
+	err := query.Order("created_at DESC").Offset(filter.Offset).Limit(filter.Limit).Find(&users).Error
+	return users, total, err
+}
+
+func (r *authRepo) SetSuspended(id uuid.UUID, at *time.Time) error {
+	return postgres.DB.Model(&entities.User{}).Where("id = ?", id).Update("suspended_at", at).Error
+}
+
+func (r *authRepo) SoftDelete(id uuid.UUID) error {
+	return postgres.DB.Where("id = ?", id).Delete(&entities.User{}).Error
+}
+
+// ReservationStats menghitung reservasi user per status dan total bayar bersih (setelah refund)
+func (r *authRepo) ReservationStats(id uuid.UUID) (*ReservationStats, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+
+	err := postgres.DB.Table("reservations").
+		Select("status, COUNT(*) AS count").
+		Where("user_id = ?", id).
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &ReservationStats{ByStatus: make(map[string]int64, len(rows))}
+	for _, row := range rows {
+		stats.ByStatus[row.Status] = row.Count
+	}
+
+	err = postgres.DB.Table("payments p").
+		Joins("JOIN reservations r ON r.id = p.reservation_id").
+		Where("r.user_id = ? AND p.status IN ?", id, []string{"CAPTURED", "PARTIALLY_REFUNDED", "REFUNDED"}).
+		Select("COALESCE(SUM(p.amount - p.refunded_amount), 0)").
+		Scan(&stats.NetSpent).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// escapeLike meng-escape wildcard LIKE supaya kata pencarian dicocokkan apa adanya
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	redis_config "movie-ticket/infra/redis"
+	"time"
+)
+
+// Percobaan login gagal dihitung per key, misal "account:<email>" atau "ip:<ip>":
+//
+//	login_fail:<key>  -> jumlah gagal dalam window
+//	login_lock:<key>  -> ada selama key sedang dikunci
+type LoginAttemptRepository interface {
+	// LockedFor mengembalikan sisa waktu kunci, 0 jika tidak sedang dikunci
+	LockedFor(key string) (time.Duration, error)
+	RegisterFailure(key string, window time.Duration) (int64, error)
+	Lock(key string, duration time.Duration) error
+	Reset(key string) error
+}
+
+type loginAttemptRepo struct{}
+
+func NewLoginAttemptRepo() LoginAttemptRepository {
+	return &loginAttemptRepo{}
+}
+
+func loginFailKey(key string) string { return "login_fail:" + key }
+
+func loginLockKey(key string) string { return "login_lock:" + key }
+
+func (r *loginAttemptRepo) LockedFor(key string) (time.Duration, error) {
+	ttl, err := redis_config.RedisClient.PTTL(context.Background(), loginLockKey(key)).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	// PTTL negatif berarti key tidak ada
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+func (r *loginAttemptRepo) RegisterFailure(key string, window time.Duration) (int64, error) {
+	ctx := context.Background()
+
+	// Window dihitung dari kegagalan pertama, tidak diperpanjang setiap gagal
+	pipe := redis_config.RedisClient.TxPipeline()
+	incr := pipe.Incr(ctx, loginFailKey(key))
+	pipe.ExpireNX(ctx, loginFailKey(key), window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
+
+func (r *loginAttemptRepo) Lock(key string, duration time.Duration) error {
+	return redis_config.RedisClient.Set(context.Background(), loginLockKey(key), 1, duration).Err()
+}
+
+func (r *loginAttemptRepo) Reset(key string) error {
+	return redis_config.RedisClient.Del(context.Background(), loginFailKey(key), loginLockKey(key)).Err()
+}
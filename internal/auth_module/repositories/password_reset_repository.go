@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	redis_config "movie-ticket/infra/redis"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Token reset disimpan di Redis dalam bentuk hash:
+//
+//	password_reset:<hash>         -> userID
+//	password_reset_user:<userID>  -> hash, supaya token lama otomatis batal saat minta token baru
+type PasswordResetRepository interface {
+	Save(userID uuid.UUID, tokenHash string, ttl time.Duration) error
+	// Consume mengambil userID pemilik token sekaligus menghapus token (sekali pakai)
+	Consume(tokenHash string) (uuid.UUID, bool, error)
+}
+
+type passwordResetRepo struct{}
+
+func NewPasswordResetRepo() PasswordResetRepository {
+	return &passwordResetRepo{}
+}
+
+func passwordResetKey(hash string) string { return "password_reset:" + hash }
+
+func passwordResetUserKey(userID uuid.UUID) string { return "password_reset_user:" + userID.String() }
+
+func (r *passwordResetRepo) Save(userID uuid.UUID, tokenHash string, ttl time.Duration) error {
+	ctx := context.Background()
+
+	previous, err := redis_config.RedisClient.Get(ctx, passwordResetUserKey(userID)).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+
+	pipe := redis_config.RedisClient.TxPipeline()
+	if previous != "" {
+		pipe.Del(ctx, passwordResetKey(previous))
+	}
+	pipe.Set(ctx, passwordResetKey(tokenHash), userID.String(), ttl)
+	pipe.Set(ctx, passwordResetUserKey(userID), tokenHash, ttl)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (r *passwordResetRepo) Consume(tokenHash string) (uuid.UUID, bool, error) {
+	ctx := context.Background()
+
+	val, err := redis_config.RedisClient.GetDel(ctx, passwordResetKey(tokenHash)).Result()
+	if errors.Is(err, redis.Nil) {
+		return uuid.Nil, false, nil
+	}
+	if err != nil {
+		return uuid.Nil, false, err
+	}
+
+	userID, err := uuid.Parse(val)
+	if err != nil {
+		return uuid.Nil, false, nil
+	}
+
+	if err := redis_config.RedisClient.Del(ctx, passwordResetUserKey(userID)).Err(); err != nil {
+		return uuid.Nil, false, err
+	}
+
+	return userID, true, nil
+}
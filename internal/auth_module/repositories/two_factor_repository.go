@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	redis_config "movie-ticket/infra/redis"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// TwoFactorChallenge adalah login yang passwordnya sudah benar tapi masih menunggu kode 2FA
+type TwoFactorChallenge struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Device    string    `json:"device"`
+	UserAgent string    `json:"user_agent"`
+	IP        string    `json:"ip"`
+}
+
+// Key Redis:
+//
+//	2fa_challenge:<hash>           -> TwoFactorChallenge
+//	2fa_challenge_attempts:<hash>  -> jumlah kode salah untuk challenge tersebut
+//	2fa_challenges:<userID>        -> sorted set hash challenge aktif milik user, score = waktu expired
+//	2fa_used:<userID>:<step>       -> penanda kode TOTP yang sudah dipakai
+type TwoFactorRepository interface {
+	// SaveChallenge menyimpan challenge baru; jika user sudah punya maxActive challenge aktif,
+	// challenge paling lama dihapus
+	SaveChallenge(tokenHash string, challenge TwoFactorChallenge, ttl time.Duration, maxActive int64) error
+	GetChallenge(tokenHash string) (*TwoFactorChallenge, error)
+	IncrementAttempts(tokenHash string, ttl time.Duration) (int64, error)
+	// DeleteChallenge bernilai false jika challenge sudah dihapus request lain
+	DeleteChallenge(userID uuid.UUID, tokenHash string) (bool, error)
+	// MarkCodeUsed bernilai false jika kode untuk step ini sudah pernah dipakai user
+	MarkCodeUsed(userID uuid.UUID, step int64, ttl time.Duration) (bool, error)
+}
+
+type twoFactorRepo struct{}
+
+func NewTwoFactorRepo() TwoFactorRepository {
+	return &twoFactorRepo{}
+}
+
+func twoFactorChallengeKey(hash string) string { return "2fa_challenge:" + hash }
+
+func twoFactorAttemptsKey(hash string) string { return "2fa_challenge_attempts:" + hash }
+
+func userChallengesKey(userID uuid.UUID) string { return "2fa_challenges:" + userID.String() }
+
+func (r *twoFactorRepo) SaveChallenge(tokenHash string, challenge TwoFactorChallenge, ttl time.Duration, maxActive int64) error {
+	ctx := context.Background()
+
+	data, err := json.Marshal(challenge)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	userKey := userChallengesKey(challenge.UserID)
+
+	pipe := redis_config.RedisClient.TxPipeline()
+	pipe.Set(ctx, twoFactorChallengeKey(tokenHash), data, ttl)
+	pipe.ZRemRangeByScore(ctx, userKey, "-inf", strconv.FormatInt(now.Unix(), 10))
+	pipe.ZAdd(ctx, userKey, redis.Z{Score: float64(now.Add(ttl).Unix()), Member: tokenHash})
+	pipe.Expire(ctx, userKey, ttl)
+	count := pipe.ZCard(ctx, userKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	if count.Val() <= maxActive {
+		return nil
+	}
+
+	// Challenge paling lama dihapus supaya satu user tidak bisa menumpuk challenge
+	evicted, err := redis_config.RedisClient.ZPopMin(ctx, userKey, count.Val()-maxActive).Result()
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, 2*len(evicted))
+	for _, z := range evicted {
+		hash, _ := z.Member.(string)
+		keys = append(keys, twoFactorChallengeKey(hash), twoFactorAttemptsKey(hash))
+	}
+
+	return redis_config.RedisClient.Del(ctx, keys...).Err()
+}
+
+func (r *twoFactorRepo) GetChallenge(tokenHash string) (*TwoFactorChallenge, error) {
+	val, err := redis_config.RedisClient.Get(context.Background(), twoFactorChallengeKey(tokenHash)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var challenge TwoFactorChallenge
+	if err := json.Unmarshal([]byte(val), &challenge); err != nil {
+		return nil, fmt.Errorf("invalid challenge data: %w", err)
+	}
+
+	return &challenge, nil
+}
+
+func (r *twoFactorRepo) IncrementAttempts(tokenHash string, ttl time.Duration) (int64, error) {
+	ctx := context.Background()
+
+	pipe := redis_config.RedisClient.TxPipeline()
+	incr := pipe.Incr(ctx, twoFactorAttemptsKey(tokenHash))
+	pipe.Expire(ctx, twoFactorAttemptsKey(tokenHash), ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
+
+func (r *twoFactorRepo) DeleteChallenge(userID uuid.UUID, tokenHash string) (bool, error) {
+	ctx := context.Background()
+
+	n, err := redis_config.RedisClient.Del(ctx, twoFactorChallengeKey(tokenHash)).Result()
+	if err != nil {
+		return false, err
+	}
+
+	pipe := redis_config.RedisClient.TxPipeline()
+	pipe.Del(ctx, twoFactorAttemptsKey(tokenHash))
+	pipe.ZRem(ctx, userChallengesKey(userID), tokenHash)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+
+	return n > 0, nil
+}
+
+func (r *twoFactorRepo) MarkCodeUsed(userID uuid.UUID, step int64, ttl time.Duration) (bool, error) {
+	key := fmt.Sprintf("2fa_used:%s:%d", userID, step)
+	return redis_config.RedisClient.SetNX(context.Background(), key, 1, ttl).Result()
+}
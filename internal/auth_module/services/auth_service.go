@@ -1,7 +1,9 @@
 package services
 
 import (
+	"errors"
 	"fmt"
+	"movie-ticket/infra/mailer"
 	customerror "movie-ticket/internal/auth_module/custom_error"
 	"movie-ticket/internal/auth_module/dto"
 	"movie-ticket/internal/auth_module/entities"
@@ -16,17 +18,45 @@ import (
 
 type AuthService interface {
 	Register(user *dto.RegisterRequest) (*dto.RegisterResponse, error)
-	Login(req *dto.LoginRequest) (*dto.UserResponse, error)
+	Login(req *dto.LoginRequest, client middleware.ClientInfo) (*dto.UserResponse, error)
 	Logout(tokenString string) error
-	RefreshToken(oldToken string) (*dto.UserResponse, error)
+	RefreshToken(refreshToken string, client middleware.ClientInfo) (*dto.UserResponse, error)
+	ListSessions(userID uuid.UUID, currentSessionID string) ([]dto.SessionResponse, error)
+	RevokeSession(userID uuid.UUID, sessionID string) error
+	LogoutAll(userID uuid.UUID) error
+	ChangePassword(userID uuid.UUID, req *dto.ChangePasswordRequest) error
+	ForgotPassword(req *dto.ForgotPasswordRequest) error
+	ResetPassword(req *dto.ResetPasswordRequest) error
+	VerifyEmail(req *dto.VerifyEmailRequest) (*dto.RegisterResponse, error)
+	ResendVerification(userID uuid.UUID) error
+	EnrollTwoFactor(userID uuid.UUID) (*dto.TwoFactorEnrollResponse, error)
+	EnableTwoFactor(userID uuid.UUID, req *dto.TwoFactorCodeRequest) (*dto.RecoveryCodesResponse, error)
+	DisableTwoFactor(userID uuid.UUID, role string, req *dto.DisableTwoFactorRequest) error
+	LoginTwoFactor(req *dto.LoginTwoFactorRequest, client middleware.ClientInfo) (*dto.UserResponse, error)
+	UnlockUser(role string, userID uuid.UUID) error
+	GetProfile(userID uuid.UUID) (*dto.RegisterResponse, error)
+	UpdateProfile(userID uuid.UUID, req *dto.UpdateProfileRequest) (*dto.RegisterResponse, error)
+	RequestEmailChange(userID uuid.UUID, req *dto.ChangeEmailRequest) error
+	ConfirmEmailChange(req *dto.ConfirmEmailChangeRequest) (*dto.RegisterResponse, error)
+	ListUsers(role string, query *dto.UserListQuery) (*dto.UserListResponse, error)
+	GetUserDetail(role string, userID uuid.UUID) (*dto.AdminUserDetailResponse, error)
+	SuspendUser(role string, callerID uuid.UUID, userID uuid.UUID) (*dto.RegisterResponse, error)
+	UnsuspendUser(role string, userID uuid.UUID) (*dto.RegisterResponse, error)
+	DeleteUser(role string, callerID uuid.UUID, userID uuid.UUID) error
+	UpdateRole(role string, callerID uuid.UUID, userID uuid.UUID, req *dto.UpdateRoleRequest) (*dto.RegisterResponse, error)
+	EnsureAdmin(email, password string) error
 }
 
 type authSvc struct {
-	repo repositories.AuthRepository
+	repo      repositories.AuthRepository
+	resets    repositories.PasswordResetRepository
+	twoFactor repositories.TwoFactorRepository
+	attempts  repositories.LoginAttemptRepository
+	mailer    mailer.Mailer
 }
 
-func NewAuthSvc(r repositories.AuthRepository) AuthService {
-	return &authSvc{repo: r}
+func NewAuthSvc(r repositories.AuthRepository, resets repositories.PasswordResetRepository, twoFactor repositories.TwoFactorRepository, attempts repositories.LoginAttemptRepository, m mailer.Mailer) AuthService {
+	return &authSvc{repo: r, resets: resets, twoFactor: twoFactor, attempts: attempts, mailer: m}
 }
 
 func (s *authSvc) Register(user *dto.RegisterRequest) (*dto.RegisterResponse, error) {
@@ -34,13 +64,25 @@ func (s *authSvc) Register(user *dto.RegisterRequest) (*dto.RegisterResponse, er
 		return nil, fmt.Errorf("%w", customerror.ErrInvalidInput)
 	}
 
-	existingUser, err := s.repo.FindByEmail(user.Email)
+	email, err := normalizeEmail(user.Email)
+	if err != nil {
+		return nil, err
+	}
+	user.Email = email
+
+	phone, err := entities.NormalizePhoneNumber(user.PhoneNumber)
+	if err != nil {
+		return nil, err
+	}
+	user.PhoneNumber = phone
+
+	taken, err := s.repo.EmailTaken(user.Email)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
 	}
 
-	if existingUser != nil {
-		return nil, fmt.Errorf("%w: %v", customerror.ErrEmailNotFound, existingUser)
+	if taken {
+		return nil, fmt.Errorf("%w", customerror.ErrEmailExist)
 	}
 
 	hash, _ := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
@@ -53,7 +95,7 @@ func (s *authSvc) Register(user *dto.RegisterRequest) (*dto.RegisterResponse, er
 		Password:    user.Password,
 		FullName:    strings.TrimSpace(user.FullName),
 		PhoneNumber: user.PhoneNumber,
-		Role:        strings.TrimSpace(user.Role),
+		Role:        entities.RoleUser, // role admin hanya bisa diberikan oleh admin lain
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
@@ -62,39 +104,66 @@ func (s *authSvc) Register(user *dto.RegisterRequest) (*dto.RegisterResponse, er
 		return nil, fmt.Errorf("%w", customerror.ErrDatabaseError)
 	}
 
+	// Gagal kirim email tidak menggagalkan registrasi, user bisa minta kirim ulang
+	if err := s.sendVerificationEmail(&users); err != nil {
+		fmt.Printf("Warning: failed to send verification email to %s: %v\n", users.Email, err)
+	}
+
 	return s.responseAuth(&users), nil
 }
 
-func (s *authSvc) Login(req *dto.LoginRequest) (*dto.UserResponse, error) {
+func (s *authSvc) Login(req *dto.LoginRequest, client middleware.ClientInfo) (*dto.UserResponse, error) {
 	if req == nil {
 		return nil, fmt.Errorf("%w", customerror.ErrInvalidInput)
 	}
 
-	existingUser, err := s.repo.FindByEmail(req.Email)
+	if err := s.checkLoginAllowed(req.Email, client.IP); err != nil {
+		return nil, err
+	}
+
+	existingUser, err := s.verifyCredentials(req.Email, req.Password)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+		if errors.Is(err, customerror.ErrInvalidCredentials) {
+			s.registerLoginFailure(req.Email, client.IP)
+		}
+		return nil, err
 	}
 
-	if existingUser == nil {
-		return nil, fmt.Errorf("%w: %v", customerror.ErrEmailNotFound, existingUser)
+	if existingUser.SuspendedAt != nil {
+		return nil, fmt.Errorf("%w", customerror.ErrAccountSuspended)
 	}
 
-	if bcrypt.CompareHashAndPassword([]byte(existingUser.Password), []byte(req.Password)) != nil {
-		return nil, fmt.Errorf("%w", customerror.ErrWrongPassword)
+	if client.Device == "" {
+		client.Device = strings.TrimSpace(req.DeviceName)
 	}
 
-	token, err := middleware.CreateToken(existingUser.ID, existingUser.Role, existingUser.Email)
+	// Token baru dibuat setelah kode 2FA diverifikasi di LoginTwoFactor, hitungan gagal
+	// per akun juga baru di-reset di sana
+	if existingUser.TOTPEnabledAt != nil {
+		return s.startTwoFactorChallenge(existingUser, client)
+	}
+
+	// Hitungan gagal per akun di-reset setelah login berhasil, hitungan per IP tidak
+	if err := s.attempts.Reset(accountAttemptKey(existingUser.Email)); err != nil {
+		fmt.Printf("Warning: failed to reset login attempts: %v\n", err)
+	}
+
+	pair, err := middleware.IssueTokenPair(existingUser.ID, existingUser.Role, existingUser.Email, client)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", customerror.ErrFailedCreateToken, err)
 	}
 
-	return &dto.UserResponse{
-		Message: "Success login",
-		Token:   token,
-	}, nil
+	return tokenResponse("Success login", pair), nil
 }
 
 func (s *authSvc) Logout(tokenString string) error {
+	// Revoke seluruh family supaya refresh token dari login ini juga tidak bisa dipakai
+	if session, err := middleware.ValidateTokenInRedis(tokenString); err == nil {
+		if err := middleware.RevokeFamily(session.FamilyID); err != nil {
+			return fmt.Errorf("failed to logout: %w", err)
+		}
+	}
+
 	// Revoke token dari Redis
 	if err := middleware.RevokeToken(tokenString); err != nil {
 		return fmt.Errorf("failed to logout: %w", err)
@@ -102,27 +171,229 @@ func (s *authSvc) Logout(tokenString string) error {
 	return nil
 }
 
-func (s *authSvc) RefreshToken(oldToken string) (*dto.UserResponse, error) {
-	// Refresh token
-	newToken, err := middleware.RefreshToken(oldToken)
+// RefreshToken menukar refresh token dengan pasangan token baru (rotation).
+// Role dan email diambil ulang dari database agar perubahan role langsung berlaku.
+func (s *authSvc) RefreshToken(refreshToken string, client middleware.ClientInfo) (*dto.UserResponse, error) {
+	session, err := middleware.ConsumeRefreshToken(refreshToken)
 	if err != nil {
+		if errors.Is(err, middleware.ErrInvalidRefreshToken) || errors.Is(err, middleware.ErrRefreshTokenReused) {
+			return nil, fmt.Errorf("%w: %v", customerror.ErrInvalidToken, err)
+		}
 		return nil, fmt.Errorf("failed to refresh token: %w", err)
 	}
 
+	user, err := s.repo.FindByID(session.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if user == nil {
+		_ = middleware.RevokeFamily(session.FamilyID)
+		return nil, fmt.Errorf("%w: user no longer exists", customerror.ErrInvalidToken)
+	}
+
+	if user.SuspendedAt != nil {
+		_ = middleware.RevokeFamily(session.FamilyID)
+		return nil, fmt.Errorf("%w: %v", customerror.ErrInvalidToken, customerror.ErrAccountSuspended)
+	}
+
+	pair, err := middleware.RotateTokenPair(session, user.Role, user.Email, client)
+	if err != nil {
+		if errors.Is(err, middleware.ErrInvalidRefreshToken) {
+			return nil, fmt.Errorf("%w: %v", customerror.ErrInvalidToken, err)
+		}
+		return nil, fmt.Errorf("%w: %v", customerror.ErrFailedCreateToken, err)
+	}
+
+	return tokenResponse("Token refreshed successfully", pair), nil
+}
+
+// ListSessions menampilkan sesi login aktif milik user, sesi yang sedang dipakai ditandai current
+func (s *authSvc) ListSessions(userID uuid.UUID, currentSessionID string) ([]dto.SessionResponse, error) {
+	sessions, err := middleware.ListSessions(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrFailedSession, err)
+	}
+
+	response := make([]dto.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		response = append(response, dto.SessionResponse{
+			ID:         session.ID,
+			Device:     session.Device,
+			UserAgent:  session.UserAgent,
+			IP:         session.IP,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			Current:    session.ID == currentSessionID,
+		})
+	}
+
+	return response, nil
+}
+
+func (s *authSvc) RevokeSession(userID uuid.UUID, sessionID string) error {
+	if err := middleware.RevokeSession(userID, sessionID); err != nil {
+		if errors.Is(err, middleware.ErrSessionNotFound) {
+			return fmt.Errorf("%w", customerror.ErrSessionNotFound)
+		}
+		return fmt.Errorf("%w: %v", customerror.ErrFailedSession, err)
+	}
+	return nil
+}
+
+func (s *authSvc) LogoutAll(userID uuid.UUID) error {
+	if err := middleware.RevokeAllSessions(userID); err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrFailedSession, err)
+	}
+	return nil
+}
+
+// ChangePassword mengganti password lalu me-revoke semua sesi, termasuk sesi yang sedang dipakai
+func (s *authSvc) ChangePassword(userID uuid.UUID, req *dto.ChangePasswordRequest) error {
+	if req == nil {
+		return fmt.Errorf("%w", customerror.ErrInvalidInput)
+	}
+
+	user, err := s.repo.FindByID(userID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if user == nil {
+		return fmt.Errorf("%w", customerror.ErrUserNotFound)
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.OldPassword)) != nil {
+		return fmt.Errorf("%w", customerror.ErrWrongPassword)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := s.repo.UpdatePassword(userID, string(hash)); err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	return s.LogoutAll(userID)
+}
+
+func tokenResponse(message string, pair *middleware.TokenPair) *dto.UserResponse {
 	return &dto.UserResponse{
-		Message: "Token refreshed successfully",
-		Token:   newToken,
-	}, nil
+		Message:      message,
+		Token:        pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		ExpiresIn:    int64(pair.ExpiresIn.Seconds()),
+	}
+}
+
+// UpdateRole mengubah role user, hanya untuk admin. Semua sesi user di-revoke supaya token
+// dengan role lama tidak bisa dipakai lagi, role baru berlaku setelah user login ulang.
+func (s *authSvc) UpdateRole(role string, callerID uuid.UUID, userID uuid.UUID, req *dto.UpdateRoleRequest) (*dto.RegisterResponse, error) {
+	if role != entities.RoleAdmin {
+		return nil, fmt.Errorf("%w", customerror.ErrUnauthorizedUser)
+	}
+
+	if req == nil {
+		return nil, fmt.Errorf("%w", customerror.ErrInvalidInput)
+	}
+
+	newRole := strings.ToLower(strings.TrimSpace(req.Role))
+	if newRole != entities.RoleUser && newRole != entities.RoleAdmin {
+		return nil, fmt.Errorf("%w: %s", customerror.ErrInvalidRole, req.Role)
+	}
+
+	// Admin tidak bisa menurunkan dirinya sendiri, supaya selalu ada minimal satu admin
+	if callerID == userID {
+		return nil, fmt.Errorf("%w", customerror.ErrSelfRoleChange)
+	}
+
+	user, err := s.repo.FindByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if user == nil {
+		return nil, fmt.Errorf("%w", customerror.ErrUserNotFound)
+	}
+
+	if user.Role != newRole {
+		if err := s.repo.UpdateRole(userID, newRole); err != nil {
+			return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+		}
+		user.Role = newRole
+		user.UpdatedAt = time.Now()
+
+		if err := s.LogoutAll(userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.responseAuth(user), nil
+}
+
+// EnsureAdmin membuat admin pertama dari env ADMIN_EMAIL/ADMIN_PASSWORD.
+// Tidak melakukan apa-apa jika sudah ada admin, dan tidak pernah mempromosikan akun yang sudah terdaftar.
+func (s *authSvc) EnsureAdmin(email, password string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" || password == "" {
+		return nil
+	}
+
+	admins, err := s.repo.CountByRole(entities.RoleAdmin)
+	if err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if admins > 0 {
+		return nil
+	}
+
+	existingUser, err := s.repo.FindByEmail(email)
+	if err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if existingUser != nil {
+		return fmt.Errorf("%w: %s is registered as %s, promote it manually", customerror.ErrEmailExist, email, existingUser.Role)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash admin password: %w", err)
+	}
+
+	now := time.Now()
+	admin := &entities.User{
+		ID:              uuid.New(),
+		Email:           email,
+		Password:        string(hash),
+		FullName:        "Administrator",
+		Role:            entities.RoleAdmin,
+		EmailVerifiedAt: &now, // email admin berasal dari env, tidak perlu verifikasi
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	if err := s.repo.Create(admin); err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	return nil
 }
 
 func (s *authSvc) responseAuth(user *entities.User) *dto.RegisterResponse {
 	return &dto.RegisterResponse{
-		ID:          user.ID,
-		Email:       user.Email,
-		FullName:    user.FullName,
-		PhoneNumber: user.PhoneNumber,
-		Role:        user.Role,
-		CreatedAt:   user.CreatedAt,
-		UpdatedAt:   user.UpdatedAt,
+		ID:               user.ID,
+		Email:            user.Email,
+		FullName:         user.FullName,
+		PhoneNumber:      user.PhoneNumber,
+		Role:             user.Role,
+		EmailVerified:    user.EmailVerifiedAt != nil,
+		TwoFactorEnabled: user.TOTPEnabledAt != nil,
+		SuspendedAt:      user.SuspendedAt,
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
 	}
 }
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"movie-ticket/config"
+	"movie-ticket/infra/mailer"
+	customerror "movie-ticket/internal/auth_module/custom_error"
+	"movie-ticket/internal/auth_module/dto"
+	"movie-ticket/internal/auth_module/entities"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Link verifikasi berlaku 24 jam (env EMAIL_VERIFICATION_TTL)
+const defaultEmailVerificationTTL = 24 * time.Hour
+
+// normalizeEmail memastikan email berformat valid tanpa display name, lalu disimpan huruf kecil
+// supaya satu alamat tidak bisa didaftarkan dua kali dengan kapitalisasi berbeda
+func normalizeEmail(raw string) (string, error) {
+	email := strings.TrimSpace(raw)
+
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return "", fmt.Errorf("%w: %s", customerror.ErrInvalidEmail, raw)
+	}
+
+	return strings.ToLower(email), nil
+}
+
+// Token verifikasi tidak disimpan, isinya "<userID>.<expiresUnix>.<signature>".
+// Signature HMAC juga mencakup email, sehingga token otomatis batal jika email diganti.
+func verificationSecret() []byte {
+	return []byte(config.GetOrDefault("EMAIL_VERIFICATION_SECRET", config.Get("JWT_SECRET")))
+}
+
+func signVerification(userID uuid.UUID, email string, expiresAt int64) string {
+	mac := hmac.New(sha256.New, verificationSecret())
+	fmt.Fprintf(mac, "%s.%d.%s", userID, expiresAt, strings.ToLower(email))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newVerificationToken(user *entities.User, expiresAt time.Time) string {
+	exp := expiresAt.Unix()
+	return fmt.Sprintf("%s.%d.%s", user.ID, exp, signVerification(user.ID, user.Email, exp))
+}
+
+func (s *authSvc) sendVerificationEmail(user *entities.User) error {
+	ttl := config.GetDuration("EMAIL_VERIFICATION_TTL", defaultEmailVerificationTTL)
+	token := newVerificationToken(user, time.Now().Add(ttl))
+	link := config.GetOrDefault("EMAIL_VERIFICATION_URL", "http://localhost:3000/verify-email") + "?token=" + url.QueryEscape(token)
+
+	return s.mailer.Send(context.Background(), mailer.Message{
+		To:      user.Email,
+		Subject: "Verifikasi email Movie Ticket",
+		Body: fmt.Sprintf(
+			"Halo %s,\n\nTerima kasih sudah mendaftar. Buka link berikut untuk memverifikasi email kamu:\n\n%s\n\nAtau gunakan token ini: %s\n\nLink berlaku %s. Kamu perlu verifikasi email sebelum bisa memesan tiket.\n",
+			user.FullName, link, token, ttl,
+		),
+	})
+}
+
+// VerifyEmail menandai email user terverifikasi. Token yang sama boleh dipakai ulang
+// selama belum expired, verifikasi kedua kali tidak mengubah apa-apa.
+func (s *authSvc) VerifyEmail(req *dto.VerifyEmailRequest) (*dto.RegisterResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w", customerror.ErrInvalidInput)
+	}
+
+	parts := strings.Split(strings.TrimSpace(req.Token), ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: verification token", customerror.ErrInvalidToken)
+	}
+
+	userID, err := uuid.Parse(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: verification token", customerror.ErrInvalidToken)
+	}
+
+	expiresAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: verification token", customerror.ErrInvalidToken)
+	}
+
+	user, err := s.repo.FindByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if user == nil || !hmac.Equal([]byte(parts[2]), []byte(signVerification(user.ID, user.Email, expiresAt))) {
+		return nil, fmt.Errorf("%w: verification token", customerror.ErrInvalidToken)
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return s.responseAuth(user), nil
+	}
+
+	if time.Now().Unix() > expiresAt {
+		return nil, fmt.Errorf("%w: verification token expired, request a new one", customerror.ErrInvalidToken)
+	}
+
+	now := time.Now()
+	if err := s.repo.MarkEmailVerified(user.ID, now); err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+	user.EmailVerifiedAt = &now
+
+	return s.responseAuth(user), nil
+}
+
+// ResendVerification mengirim ulang link verifikasi untuk user yang sedang login
+func (s *authSvc) ResendVerification(userID uuid.UUID) error {
+	user, err := s.repo.FindByID(userID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if user == nil {
+		return fmt.Errorf("%w", customerror.ErrUserNotFound)
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return fmt.Errorf("%w", customerror.ErrAlreadyVerified)
+	}
+
+	if err := s.sendVerificationEmail(user); err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+
+	return nil
+}
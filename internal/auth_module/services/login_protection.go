@@ -0,0 +1,143 @@
+package services
+
+import (
+	"fmt"
+	"movie-ticket/config"
+	customerror "movie-ticket/internal/auth_module/custom_error"
+	"movie-ticket/internal/auth_module/entities"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Default proteksi login, bisa diatur lewat env:
+//   - LOGIN_MAX_ATTEMPTS: setiap kelipatan N gagal per akun, akun dikunci
+//   - LOGIN_IP_MAX_ATTEMPTS: N gagal dari satu IP (semua akun) membuat IP diblok
+//   - LOGIN_ATTEMPT_WINDOW: rentang waktu penghitungan gagal
+//   - LOGIN_LOCKOUT: lama kunci pertama, berlipat dua untuk setiap kunci berikutnya (maks 1 jam)
+const (
+	defaultLoginMaxAttempts   = 5
+	defaultLoginIPMaxAttempts = 20
+	defaultLoginAttemptWindow = time.Hour
+	defaultLoginLockout       = time.Minute
+	maxLoginLockout           = time.Hour
+)
+
+// dummyPasswordHash dipakai saat email tidak ditemukan supaya waktu respon sama dengan password salah
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("movie-ticket-dummy-password"), bcrypt.DefaultCost)
+
+func accountAttemptKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipAttemptKey(ip string) string {
+	return "ip:" + ip
+}
+
+func loginLimit(key string, fallback int) int64 {
+	if n, err := strconv.Atoi(config.Get(key)); err == nil && n > 0 {
+		return int64(n)
+	}
+	return int64(fallback)
+}
+
+// checkLoginAllowed menolak login dari IP atau akun yang sedang dikunci
+func (s *authSvc) checkLoginAllowed(email, ip string) error {
+	if ip != "" {
+		wait, err := s.attempts.LockedFor(ipAttemptKey(ip))
+		if err != nil {
+			return fmt.Errorf("%w: %v", customerror.ErrFailedSession, err)
+		}
+		if wait > 0 {
+			return fmt.Errorf("%w, try again in %s", customerror.ErrTooManyAttempts, wait.Round(time.Second))
+		}
+	}
+
+	wait, err := s.attempts.LockedFor(accountAttemptKey(email))
+	if err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrFailedSession, err)
+	}
+	if wait > 0 {
+		return fmt.Errorf("%w, try again in %s", customerror.ErrAccountLocked, wait.Round(time.Second))
+	}
+
+	return nil
+}
+
+// registerLoginFailure mencatat login gagal dan mengunci akun/IP jika melewati batas.
+// Akun yang tidak terdaftar tetap dihitung agar perilakunya sama dengan akun yang ada.
+func (s *authSvc) registerLoginFailure(email, ip string) {
+	window := config.GetDuration("LOGIN_ATTEMPT_WINDOW", defaultLoginAttemptWindow)
+
+	maxAttempts := loginLimit("LOGIN_MAX_ATTEMPTS", defaultLoginMaxAttempts)
+	failures, err := s.attempts.RegisterFailure(accountAttemptKey(email), window)
+	if err != nil {
+		fmt.Printf("Warning: failed to record login failure: %v\n", err)
+	} else if failures%maxAttempts == 0 {
+		lockout := config.GetDuration("LOGIN_LOCKOUT", defaultLoginLockout)
+		for i := int64(1); i < failures/maxAttempts && lockout < maxLoginLockout; i++ {
+			lockout *= 2
+		}
+		if lockout > maxLoginLockout {
+			lockout = maxLoginLockout
+		}
+
+		if err := s.attempts.Lock(accountAttemptKey(email), lockout); err != nil {
+			fmt.Printf("Warning: failed to lock account: %v\n", err)
+		}
+	}
+
+	if ip == "" {
+		return
+	}
+
+	ipFailures, err := s.attempts.RegisterFailure(ipAttemptKey(ip), window)
+	if err != nil {
+		fmt.Printf("Warning: failed to record login failure: %v\n", err)
+	} else if ipFailures >= loginLimit("LOGIN_IP_MAX_ATTEMPTS", defaultLoginIPMaxAttempts) {
+		if err := s.attempts.Lock(ipAttemptKey(ip), window); err != nil {
+			fmt.Printf("Warning: failed to block ip: %v\n", err)
+		}
+	}
+}
+
+// verifyCredentials mengembalikan user jika email dan password cocok.
+// Email tidak ditemukan dan password salah sama-sama menjadi ErrInvalidCredentials.
+func (s *authSvc) verifyCredentials(email, password string) (*entities.User, error) {
+	user, err := s.repo.FindByEmail(strings.TrimSpace(email))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	hash := dummyPasswordHash
+	if user != nil {
+		hash = []byte(user.Password)
+	}
+
+	if bcrypt.CompareHashAndPassword(hash, []byte(password)) != nil || user == nil {
+		return nil, fmt.Errorf("%w", customerror.ErrInvalidCredentials)
+	}
+
+	return user, nil
+}
+
+// UnlockUser menghapus kunci login dan hitungan gagal sebuah akun, hanya untuk admin
+func (s *authSvc) UnlockUser(role string, userID uuid.UUID) error {
+	if role != entities.RoleAdmin {
+		return fmt.Errorf("%w", customerror.ErrUnauthorizedUser)
+	}
+
+	user, err := s.findUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.attempts.Reset(accountAttemptKey(user.Email)); err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrFailedSession, err)
+	}
+
+	return nil
+}
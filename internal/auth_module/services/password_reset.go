@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"movie-ticket/config"
+	"movie-ticket/infra/mailer"
+	customerror "movie-ticket/internal/auth_module/custom_error"
+	"movie-ticket/internal/auth_module/dto"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Token reset berlaku 30 menit (env PASSWORD_RESET_TTL) dan hanya bisa dipakai sekali
+const defaultPasswordResetTTL = 30 * time.Minute
+
+// ForgotPassword mengirim token reset ke email user. Email yang tidak terdaftar
+// tetap dianggap sukses supaya endpoint tidak bisa dipakai untuk menebak email.
+func (s *authSvc) ForgotPassword(req *dto.ForgotPasswordRequest) error {
+	if req == nil {
+		return fmt.Errorf("%w", customerror.ErrInvalidInput)
+	}
+
+	email := strings.TrimSpace(req.Email)
+
+	user, err := s.repo.FindByEmail(email)
+	if err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if user == nil {
+		return nil
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate reset token: %w", err)
+	}
+
+	ttl := config.GetDuration("PASSWORD_RESET_TTL", defaultPasswordResetTTL)
+	if err := s.resets.Save(user.ID, hashToken(token), ttl); err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrFailedSession, err)
+	}
+
+	link := config.GetOrDefault("PASSWORD_RESET_URL", "http://localhost:3000/reset-password") + "?token=" + url.QueryEscape(token)
+
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: "Reset password Movie Ticket",
+		Body: fmt.Sprintf(
+			"Halo %s,\n\nKami menerima permintaan reset password untuk akun kamu.\nBuka link berikut untuk membuat password baru:\n\n%s\n\nAtau gunakan token ini: %s\n\nLink berlaku %s dan hanya bisa dipakai sekali. Abaikan email ini jika kamu tidak meminta reset password.\n",
+			user.FullName, link, token, ttl,
+		),
+	}
+
+	// Kegagalan kirim email tidak dikembalikan ke client, response tetap sama untuk semua email
+	if err := s.mailer.Send(context.Background(), msg); err != nil {
+		fmt.Printf("Warning: failed to send reset password email to %s: %v\n", user.Email, err)
+	}
+
+	return nil
+}
+
+// ResetPassword mengganti password memakai token dari email, lalu me-revoke semua sesi user
+func (s *authSvc) ResetPassword(req *dto.ResetPasswordRequest) error {
+	if req == nil {
+		return fmt.Errorf("%w", customerror.ErrInvalidInput)
+	}
+
+	userID, ok, err := s.resets.Consume(hashToken(strings.TrimSpace(req.Token)))
+	if err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrFailedSession, err)
+	}
+
+	if !ok {
+		return fmt.Errorf("%w: reset token", customerror.ErrInvalidToken)
+	}
+
+	user, err := s.repo.FindByID(userID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if user == nil {
+		return fmt.Errorf("%w", customerror.ErrUserNotFound)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if err := s.repo.UpdatePassword(user.ID, string(hash)); err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	return s.LogoutAll(user.ID)
+}
+
+// generateToken membuat token acak yang aman untuk dikirim lewat URL
+func generateToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashToken dipakai agar token mentah tidak pernah disimpan
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
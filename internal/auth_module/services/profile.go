@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"movie-ticket/config"
+	"movie-ticket/infra/mailer"
+	customerror "movie-ticket/internal/auth_module/custom_error"
+	"movie-ticket/internal/auth_module/dto"
+	"movie-ticket/internal/auth_module/entities"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func (s *authSvc) GetProfile(userID uuid.UUID) (*dto.RegisterResponse, error) {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.responseAuth(user), nil
+}
+
+// UpdateProfile mengubah nama dan/atau nomor telepon. Field yang tidak dikirim tidak diubah.
+func (s *authSvc) UpdateProfile(userID uuid.UUID, req *dto.UpdateProfileRequest) (*dto.RegisterResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w", customerror.ErrInvalidInput)
+	}
+
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.FullName != nil {
+		fullName := strings.TrimSpace(*req.FullName)
+		if fullName == "" {
+			return nil, fmt.Errorf("%w: full_name cannot be empty", customerror.ErrInvalidInput)
+		}
+		user.FullName = fullName
+	}
+
+	if req.PhoneNumber != nil {
+		phone, err := entities.NormalizePhoneNumber(*req.PhoneNumber)
+		if err != nil {
+			return nil, err
+		}
+		user.PhoneNumber = phone
+	}
+
+	if err := s.repo.UpdateProfile(user.ID, user.FullName, user.PhoneNumber); err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+	user.UpdatedAt = time.Now()
+
+	return s.responseAuth(user), nil
+}
+
+// Token ganti email tidak disimpan: base64url("<userID>|<emailBaru>|<expiresUnix>") + "." + signature.
+// Signature mencakup email lama, jadi token batal jika email sudah berubah lewat jalur lain.
+func signEmailChange(payload, currentEmail string) string {
+	mac := hmac.New(sha256.New, verificationSecret())
+	fmt.Fprintf(mac, "email-change.%s.%s", payload, strings.ToLower(currentEmail))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// RequestEmailChange mengirim link konfirmasi ke email baru. Email akun baru berubah
+// setelah link dikonfirmasi, sampai saat itu login tetap memakai email lama.
+func (s *authSvc) RequestEmailChange(userID uuid.UUID, req *dto.ChangeEmailRequest) error {
+	if req == nil {
+		return fmt.Errorf("%w", customerror.ErrInvalidInput)
+	}
+
+	newEmail, err := normalizeEmail(req.NewEmail)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.findUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)) != nil {
+		return fmt.Errorf("%w", customerror.ErrWrongPassword)
+	}
+
+	if strings.EqualFold(newEmail, user.Email) {
+		return fmt.Errorf("%w: new email is the same as the current one", customerror.ErrInvalidInput)
+	}
+
+	taken, err := s.repo.EmailTaken(newEmail)
+	if err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if taken {
+		return fmt.Errorf("%w", customerror.ErrEmailExist)
+	}
+
+	ttl := config.GetDuration("EMAIL_VERIFICATION_TTL", defaultEmailVerificationTTL)
+	payload := base64.RawURLEncoding.EncodeToString(
+		[]byte(fmt.Sprintf("%s|%s|%d", user.ID, newEmail, time.Now().Add(ttl).Unix())),
+	)
+	token := payload + "." + signEmailChange(payload, user.Email)
+	link := config.GetOrDefault("EMAIL_CHANGE_URL", "http://localhost:3000/confirm-email") + "?token=" + url.QueryEscape(token)
+
+	err = s.mailer.Send(context.Background(), mailer.Message{
+		To:      newEmail,
+		Subject: "Konfirmasi email baru Movie Ticket",
+		Body: fmt.Sprintf(
+			"Halo %s,\n\nBuka link berikut untuk menjadikan %s sebagai email akun Movie Ticket kamu:\n\n%s\n\nAtau gunakan token ini: %s\n\nLink berlaku %s. Abaikan email ini jika kamu tidak meminta perubahan email.\n",
+			user.FullName, newEmail, link, token, ttl,
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send confirmation email: %w", err)
+	}
+
+	return nil
+}
+
+// ConfirmEmailChange mengganti email akun. Email baru langsung terverifikasi karena
+// token hanya bisa didapat dari inbox email tersebut, dan email lama diberi pemberitahuan.
+func (s *authSvc) ConfirmEmailChange(req *dto.ConfirmEmailChangeRequest) (*dto.RegisterResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w", customerror.ErrInvalidInput)
+	}
+
+	invalid := fmt.Errorf("%w: email change token", customerror.ErrInvalidToken)
+
+	payload, signature, ok := strings.Cut(strings.TrimSpace(req.Token), ".")
+	if !ok {
+		return nil, invalid
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, invalid
+	}
+
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 3 {
+		return nil, invalid
+	}
+
+	userID, err := uuid.Parse(parts[0])
+	if err != nil {
+		return nil, invalid
+	}
+	newEmail := parts[1]
+
+	expiresAt, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return nil, invalid
+	}
+
+	user, err := s.repo.FindByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if user == nil || !hmac.Equal([]byte(signature), []byte(signEmailChange(payload, user.Email))) {
+		return nil, invalid
+	}
+
+	if time.Now().Unix() > expiresAt {
+		return nil, fmt.Errorf("%w: email change token expired, request a new one", customerror.ErrInvalidToken)
+	}
+
+	taken, err := s.repo.EmailTaken(newEmail)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if taken {
+		return nil, fmt.Errorf("%w", customerror.ErrEmailExist)
+	}
+
+	oldEmail := user.Email
+	now := time.Now()
+	if err := s.repo.UpdateEmail(user.ID, newEmail, now); err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+	user.Email = newEmail
+	user.EmailVerifiedAt = &now
+	user.UpdatedAt = now
+
+	// Sesi lama masih membawa email lama, user diminta login ulang dengan email baru
+	if err := s.LogoutAll(user.ID); err != nil {
+		fmt.Printf("Warning: failed to revoke sessions after email change: %v\n", err)
+	}
+
+	err = s.mailer.Send(context.Background(), mailer.Message{
+		To:      oldEmail,
+		Subject: "Email akun Movie Ticket telah diganti",
+		Body: fmt.Sprintf(
+			"Halo %s,\n\nEmail akun Movie Ticket kamu telah diganti dari %s menjadi %s.\nJika kamu tidak melakukan perubahan ini, segera hubungi tim kami.\n",
+			user.FullName, oldEmail, newEmail,
+		),
+	})
+	if err != nil {
+		fmt.Printf("Warning: failed to notify old email %s: %v\n", oldEmail, err)
+	}
+
+	return s.responseAuth(user), nil
+}
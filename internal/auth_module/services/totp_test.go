@@ -0,0 +1,58 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+// Secret dan kode dari test vector RFC 6238 (SHA1), diambil 6 digit terakhir
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestValidateTOTP(t *testing.T) {
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		now      int64
+		wantStep int64
+		wantOK   bool
+	}{
+		{"vector T=59", rfc6238Secret, "287082", 59, 1, true},
+		{"vector T=1111111109", rfc6238Secret, "081804", 1111111109, 37037036, true},
+		{"vector T=1234567890", rfc6238Secret, "005924", 1234567890, 41152263, true},
+		{"secret huruf kecil", "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", "005924", 1234567890, 41152263, true},
+		{"spasi di sekitar kode", rfc6238Secret, " 005924 ", 1234567890, 41152263, true},
+		{"kode periode sebelumnya", rfc6238Secret, "005924", 1234567890 + totpPeriod, 41152263, true},
+		{"kode periode berikutnya", rfc6238Secret, "005924", 1234567890 - totpPeriod, 41152263, true},
+		{"di luar toleransi", rfc6238Secret, "005924", 1234567890 + 2*totpPeriod, 0, false},
+		{"kode salah", rfc6238Secret, "005925", 1234567890, 0, false},
+		{"panjang kode salah", rfc6238Secret, "05924", 1234567890, 0, false},
+		{"secret tidak valid", "not-base32!", "005924", 1234567890, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := validateTOTP(tt.secret, tt.code, time.Unix(tt.now, 0))
+			if ok != tt.wantOK || step != tt.wantStep {
+				t.Errorf("validateTOTP = (%d, %v), want (%d, %v)", step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNewTOTPSecret(t *testing.T) {
+	secret, err := newTOTPSecret()
+	if err != nil {
+		t.Fatalf("newTOTPSecret: %v", err)
+	}
+
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil || len(key) != 20 {
+		t.Fatalf("secret %q is not 20 bytes of base32: %v", secret, err)
+	}
+
+	code := hotp(key, uint64(time.Now().Unix()/totpPeriod))
+	if _, ok := validateTOTP(secret, code, time.Now()); !ok {
+		t.Errorf("code %s for fresh secret was rejected", code)
+	}
+}
@@ -0,0 +1,319 @@
+package services
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"movie-ticket/config"
+	customerror "movie-ticket/internal/auth_module/custom_error"
+	"movie-ticket/internal/auth_module/dto"
+	"movie-ticket/internal/auth_module/entities"
+	"movie-ticket/internal/auth_module/repositories"
+	"movie-ticket/internal/middleware"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// Challenge login 2FA berlaku 5 menit dan hangus setelah 5 kode salah. Kode salah juga
+	// dihitung sebagai login gagal akun, dan satu user maksimal punya 3 challenge aktif.
+	twoFactorChallengeTTL = 5 * time.Minute
+	twoFactorMaxAttempts  = 5
+	twoFactorMaxActive    = 3
+
+	recoveryCodeCount = 10
+)
+
+// EnrollTwoFactor membuat secret baru (belum aktif) dan URI untuk aplikasi authenticator.
+// Enroll ulang sebelum enable akan mengganti secret sebelumnya.
+func (s *authSvc) EnrollTwoFactor(userID uuid.UUID) (*dto.TwoFactorEnrollResponse, error) {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabledAt != nil {
+		return nil, fmt.Errorf("%w", customerror.ErrTwoFactorEnabled)
+	}
+
+	secret, err := newTOTPSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate totp secret: %w", err)
+	}
+
+	if err := s.repo.UpdateTwoFactor(user.ID, secret, nil, ""); err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	return &dto.TwoFactorEnrollResponse{
+		Secret:     secret,
+		OtpauthURI: totpURI(config.GetOrDefault("TOTP_ISSUER", "Movie Ticket"), user.Email, secret),
+	}, nil
+}
+
+// EnableTwoFactor mengaktifkan 2FA setelah kode pertama dari authenticator cocok.
+// Recovery code hanya ditampilkan sekali di sini.
+func (s *authSvc) EnableTwoFactor(userID uuid.UUID, req *dto.TwoFactorCodeRequest) (*dto.RecoveryCodesResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w", customerror.ErrInvalidInput)
+	}
+
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabledAt != nil {
+		return nil, fmt.Errorf("%w", customerror.ErrTwoFactorEnabled)
+	}
+
+	if user.TOTPSecret == "" {
+		return nil, fmt.Errorf("%w: enroll first", customerror.ErrTwoFactorNotEnabled)
+	}
+
+	if err := s.checkTOTP(user, req.Code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate recovery codes: %w", err)
+	}
+
+	now := time.Now()
+	if err := s.repo.UpdateTwoFactor(user.ID, user.TOTPSecret, &now, strings.Join(hashes, ",")); err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	return &dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// DisableTwoFactor mematikan 2FA, butuh password dan kode TOTP/recovery code.
+// Admin tidak bisa mematikan 2FA jika ADMIN_REQUIRE_2FA aktif.
+func (s *authSvc) DisableTwoFactor(userID uuid.UUID, role string, req *dto.DisableTwoFactorRequest) error {
+	if req == nil {
+		return fmt.Errorf("%w", customerror.ErrInvalidInput)
+	}
+
+	if role == entities.RoleAdmin && middleware.AdminTwoFactorRequired() {
+		return fmt.Errorf("%w", customerror.ErrTwoFactorRequired)
+	}
+
+	user, err := s.findUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.TOTPEnabledAt == nil {
+		return fmt.Errorf("%w", customerror.ErrTwoFactorNotEnabled)
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)) != nil {
+		return fmt.Errorf("%w", customerror.ErrWrongPassword)
+	}
+
+	if err := s.checkSecondFactor(user, req.Code); err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateTwoFactor(user.ID, "", nil, ""); err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	return nil
+}
+
+// startTwoFactorChallenge dipanggil Login setelah password benar untuk akun dengan 2FA aktif
+func (s *authSvc) startTwoFactorChallenge(user *entities.User, client middleware.ClientInfo) (*dto.UserResponse, error) {
+	token, err := generateToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate challenge token: %w", err)
+	}
+
+	challenge := repositories.TwoFactorChallenge{
+		UserID:    user.ID,
+		Device:    client.Device,
+		UserAgent: client.UserAgent,
+		IP:        client.IP,
+	}
+
+	if err := s.twoFactor.SaveChallenge(hashToken(token), challenge, twoFactorChallengeTTL, twoFactorMaxActive); err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrFailedSession, err)
+	}
+
+	return &dto.UserResponse{
+		Message:           "Two-factor authentication required",
+		TwoFactorRequired: true,
+		ChallengeToken:    token,
+	}, nil
+}
+
+// LoginTwoFactor menyelesaikan login 2FA dan baru membuat access/refresh token di sini
+func (s *authSvc) LoginTwoFactor(req *dto.LoginTwoFactorRequest, client middleware.ClientInfo) (*dto.UserResponse, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w", customerror.ErrInvalidInput)
+	}
+
+	hash := hashToken(strings.TrimSpace(req.ChallengeToken))
+
+	challenge, err := s.twoFactor.GetChallenge(hash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrFailedSession, err)
+	}
+
+	if challenge == nil {
+		return nil, fmt.Errorf("%w: challenge token", customerror.ErrInvalidToken)
+	}
+
+	user, err := s.findUser(challenge.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabledAt == nil {
+		return nil, fmt.Errorf("%w: challenge token", customerror.ErrInvalidToken)
+	}
+
+	if user.SuspendedAt != nil {
+		_, _ = s.twoFactor.DeleteChallenge(user.ID, hash)
+		return nil, fmt.Errorf("%w", customerror.ErrAccountSuspended)
+	}
+
+	// Akun yang terkunci karena kode salah di challenge lain juga tidak bisa lanjut di sini
+	if err := s.checkLoginAllowed(user.Email, client.IP); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkSecondFactor(user, req.Code); err != nil {
+		if !errors.Is(err, customerror.ErrInvalidTwoFactorCode) {
+			return nil, err
+		}
+
+		s.registerLoginFailure(user.Email, client.IP)
+
+		attempts, countErr := s.twoFactor.IncrementAttempts(hash, twoFactorChallengeTTL)
+		if countErr == nil && attempts >= twoFactorMaxAttempts {
+			_, _ = s.twoFactor.DeleteChallenge(user.ID, hash)
+			return nil, fmt.Errorf("%w: too many attempts, login again", customerror.ErrInvalidToken)
+		}
+		return nil, err
+	}
+
+	// Challenge hanya bisa ditukar sekali walaupun ada dua request bersamaan
+	deleted, err := s.twoFactor.DeleteChallenge(user.ID, hash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrFailedSession, err)
+	}
+
+	if !deleted {
+		return nil, fmt.Errorf("%w: challenge token", customerror.ErrInvalidToken)
+	}
+
+	// Hitungan gagal per akun baru di-reset setelah 2FA berhasil
+	if err := s.attempts.Reset(accountAttemptKey(user.Email)); err != nil {
+		fmt.Printf("Warning: failed to reset login attempts: %v\n", err)
+	}
+
+	pair, err := middleware.IssueTokenPair(user.ID, user.Role, user.Email, middleware.ClientInfo{
+		Device:    challenge.Device,
+		UserAgent: challenge.UserAgent,
+		IP:        client.IP,
+		TwoFactor: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrFailedCreateToken, err)
+	}
+
+	return tokenResponse("Success login", pair), nil
+}
+
+func (s *authSvc) findUser(userID uuid.UUID) (*entities.User, error) {
+	user, err := s.repo.FindByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if user == nil {
+		return nil, fmt.Errorf("%w", customerror.ErrUserNotFound)
+	}
+
+	return user, nil
+}
+
+// checkTOTP memvalidasi kode TOTP, kode yang sama tidak bisa dipakai dua kali
+func (s *authSvc) checkTOTP(user *entities.User, code string) error {
+	step, ok := validateTOTP(user.TOTPSecret, code, time.Now())
+	if !ok {
+		return fmt.Errorf("%w", customerror.ErrInvalidTwoFactorCode)
+	}
+
+	fresh, err := s.twoFactor.MarkCodeUsed(user.ID, step, time.Duration(2*totpSkew+1)*totpPeriod*time.Second)
+	if err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrFailedSession, err)
+	}
+
+	if !fresh {
+		return fmt.Errorf("%w: code already used", customerror.ErrInvalidTwoFactorCode)
+	}
+
+	return nil
+}
+
+// checkSecondFactor menerima kode TOTP atau recovery code. Recovery code langsung dihapus setelah dipakai.
+func (s *authSvc) checkSecondFactor(user *entities.User, code string) error {
+	code = strings.TrimSpace(code)
+	if len(code) == totpDigits {
+		return s.checkTOTP(user, code)
+	}
+
+	consumed, err := s.repo.ConsumeRecoveryCode(user.ID, hashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if !consumed {
+		return fmt.Errorf("%w", customerror.ErrInvalidTwoFactorCode)
+	}
+
+	return nil
+}
+
+// Recovery code berformat "xxxxx-xxxxx" dari alfabet tanpa huruf/angka yang mirip
+const recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+
+	// rand.Int memilih karakter secara merata, byte % 31 membuat sebagian karakter lebih sering muncul
+	alphabetSize := big.NewInt(int64(len(recoveryCodeAlphabet)))
+	for i := 0; i < recoveryCodeCount; i++ {
+		var b strings.Builder
+		for j := 0; j < 10; j++ {
+			if j == 5 {
+				b.WriteByte('-')
+			}
+
+			n, err := rand.Int(rand.Reader, alphabetSize)
+			if err != nil {
+				return nil, nil, err
+			}
+			b.WriteByte(recoveryCodeAlphabet[n.Int64()])
+		}
+
+		code := b.String()
+		codes = append(codes, code)
+		hashes = append(hashes, hashToken(normalizeRecoveryCode(code)))
+	}
+
+	return codes, hashes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"fmt"
+	customerror "movie-ticket/internal/auth_module/custom_error"
+	"movie-ticket/internal/auth_module/dto"
+	"movie-ticket/internal/auth_module/entities"
+	"movie-ticket/internal/auth_module/repositories"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultUserPageLimit = 10
+	maxUserPageLimit     = 100
+)
+
+// ListUsers menampilkan daftar user untuk admin, bisa dicari (email/nama/telepon) dan difilter role/status
+func (s *authSvc) ListUsers(role string, query *dto.UserListQuery) (*dto.UserListResponse, error) {
+	if role != entities.RoleAdmin {
+		return nil, fmt.Errorf("%w", customerror.ErrUnauthorizedUser)
+	}
+
+	if query == nil {
+		query = &dto.UserListQuery{}
+	}
+
+	page := query.Page
+	if page < 1 {
+		page = 1
+	}
+
+	limit := query.Limit
+	if limit < 1 {
+		limit = defaultUserPageLimit
+	}
+	if limit > maxUserPageLimit {
+		limit = maxUserPageLimit
+	}
+
+	users, total, err := s.repo.List(repositories.UserFilter{
+		Search: strings.TrimSpace(query.Search),
+		Role:   query.Role,
+		Status: query.Status,
+		Offset: (page - 1) * limit,
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	data := make([]dto.RegisterResponse, 0, len(users))
+	for i := range users {
+		data = append(data, *s.responseAuth(&users[i]))
+	}
+
+	return &dto.UserListResponse{
+		Data:       data,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	}, nil
+}
+
+// GetUserDetail menampilkan profil user beserta ringkasan reservasi dan total pembayarannya
+func (s *authSvc) GetUserDetail(role string, userID uuid.UUID) (*dto.AdminUserDetailResponse, error) {
+	if role != entities.RoleAdmin {
+		return nil, fmt.Errorf("%w", customerror.ErrUnauthorizedUser)
+	}
+
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.repo.ReservationStats(user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	summary := dto.ReservationSummary{ByStatus: stats.ByStatus, NetSpent: stats.NetSpent}
+	for _, count := range stats.ByStatus {
+		summary.Total += count
+	}
+
+	return &dto.AdminUserDetailResponse{
+		RegisterResponse: *s.responseAuth(user),
+		Reservations:     summary,
+	}, nil
+}
+
+// SuspendUser memblokir login user dan langsung mencabut semua sesinya
+func (s *authSvc) SuspendUser(role string, callerID uuid.UUID, userID uuid.UUID) (*dto.RegisterResponse, error) {
+	if role != entities.RoleAdmin {
+		return nil, fmt.Errorf("%w", customerror.ErrUnauthorizedUser)
+	}
+
+	if callerID == userID {
+		return nil, fmt.Errorf("%w", customerror.ErrSelfAction)
+	}
+
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.SuspendedAt == nil {
+		now := time.Now()
+		if err := s.repo.SetSuspended(user.ID, &now); err != nil {
+			return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+		}
+		user.SuspendedAt = &now
+		user.UpdatedAt = now
+	}
+
+	// Tetap dicabut walaupun sudah disuspend, untuk jaga-jaga ada sesi yang tersisa
+	if err := s.LogoutAll(user.ID); err != nil {
+		return nil, err
+	}
+
+	return s.responseAuth(user), nil
+}
+
+func (s *authSvc) UnsuspendUser(role string, userID uuid.UUID) (*dto.RegisterResponse, error) {
+	if role != entities.RoleAdmin {
+		return nil, fmt.Errorf("%w", customerror.ErrUnauthorizedUser)
+	}
+
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.SuspendedAt != nil {
+		if err := s.repo.SetSuspended(user.ID, nil); err != nil {
+			return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+		}
+		user.SuspendedAt = nil
+		user.UpdatedAt = time.Now()
+	}
+
+	return s.responseAuth(user), nil
+}
+
+// DeleteUser menghapus user secara soft delete. Data reservasi dan pembayaran tetap tersimpan,
+// dan email user tersebut tidak bisa dipakai untuk mendaftar lagi.
+func (s *authSvc) DeleteUser(role string, callerID uuid.UUID, userID uuid.UUID) error {
+	if role != entities.RoleAdmin {
+		return fmt.Errorf("%w", customerror.ErrUnauthorizedUser)
+	}
+
+	if callerID == userID {
+		return fmt.Errorf("%w", customerror.ErrSelfAction)
+	}
+
+	user, err := s.findUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.SoftDelete(user.ID); err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	return s.LogoutAll(user.ID)
+}
@@ -21,9 +21,17 @@ const (
 	ContextKeyRole  = "user_role"
 	ContextKeyID    = "user_id"
 	ContextKeyEmail = "user_email"
-	TokenExpiry     = 24 * time.Hour
+	// ContextKeyTwoFactor bernilai true jika login sesi ini diselesaikan dengan 2FA
+	ContextKeyTwoFactor = "user_two_factor"
+
+	defaultAccessTokenExpiry = 15 * time.Minute
 )
 
+// AccessTokenExpiry adalah umur access token (env ACCESS_TOKEN_TTL, default 15m)
+func AccessTokenExpiry() time.Duration {
+	return config.GetDuration("ACCESS_TOKEN_TTL", defaultAccessTokenExpiry)
+}
+
 // Custom JWT Claims
 // ID disimpan sebagai string dalam JWT untuk kompatibilitas, tapi akan dikonversi ke UUID saat digunakan
 type CustomClaims struct {
@@ -33,15 +41,19 @@ type CustomClaims struct {
 	jwt.RegisteredClaims
 }
 
-// CreateToken membuat JWT token baru dan menyimpan session ke Redis
-func CreateToken(id uuid.UUID, role, email string) (string, error) {
+// CreateToken membuat JWT access token baru dan menyimpan session ke Redis.
+// familyID mengikat access token ke refresh token family-nya, sehingga ikut mati saat family di-revoke.
+// twoFactor menandai sesi yang login-nya sudah melewati verifikasi 2FA.
+func CreateToken(id uuid.UUID, role, email, familyID string, twoFactor bool) (string, error) {
+	expiry := AccessTokenExpiry()
+
 	// Buat claims
 	claims := CustomClaims{
 		ID:    id.String(),
 		Email: email,
 		Role:  role,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(TokenExpiry)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiry)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 			Issuer:    "movie-ticket-app",
@@ -58,9 +70,11 @@ func CreateToken(id uuid.UUID, role, email string) (string, error) {
 
 	// Simpan session ke Redis
 	session := dto.UserSession{
-		ID:    id,
-		Email: email,
-		Role:  role,
+		ID:        id,
+		Email:     email,
+		Role:      role,
+		FamilyID:  familyID,
+		TwoFactor: twoFactor,
 	}
 
 	sessionJSON, err := json.Marshal(session)
@@ -70,7 +84,7 @@ func CreateToken(id uuid.UUID, role, email string) (string, error) {
 
 	// Set ke Redis dengan TTL
 	ctx := context.Background()
-	err = redis_config.RedisClient.Set(ctx, tokenString, sessionJSON, TokenExpiry).Err()
+	err = redis_config.RedisClient.Set(ctx, tokenString, sessionJSON, expiry).Err()
 	if err != nil {
 		return "", fmt.Errorf("failed to save session to redis: %w", err)
 	}
@@ -140,6 +154,17 @@ func ValidateTokenInRedis(tokenString string) (*dto.UserSession, error) {
 		return nil, fmt.Errorf("invalid session data: %w", err)
 	}
 
+	// Access token tidak berlaku lagi jika refresh token family-nya sudah di-revoke
+	if session.FamilyID != "" {
+		active, err := isFamilyActive(ctx, session.FamilyID)
+		if err != nil {
+			return nil, fmt.Errorf("redis error: %w", err)
+		}
+		if !active {
+			return nil, errors.New("session has been revoked")
+		}
+	}
+
 	return &session, nil
 }
 
@@ -153,39 +178,6 @@ func RevokeToken(tokenString string) error {
 	return nil
 }
 
-// RefreshToken membuat token baru dan menghapus token lama
-func RefreshToken(oldTokenString string) (string, error) {
-	// Validasi token lama
-	_, claims, err := ParseToken(oldTokenString)
-	if err != nil {
-		return "", fmt.Errorf("invalid old token: %w", err)
-	}
-
-	// Validasi session di Redis
-	session, err := ValidateTokenInRedis(oldTokenString)
-	if err != nil {
-		return "", fmt.Errorf("session validation failed: %w", err)
-	}
-
-	// Hapus token lama
-	if err := RevokeToken(oldTokenString); err != nil {
-		return "", fmt.Errorf("failed to revoke old token: %w", err)
-	}
-
-	// Buat token baru
-	userID, err := uuid.Parse(claims.ID)
-	if err != nil {
-		return "", fmt.Errorf("invalid user ID in claims: %w", err)
-	}
-
-	newToken, err := CreateToken(userID, session.Role, session.Email)
-	if err != nil {
-		return "", fmt.Errorf("failed to create new token: %w", err)
-	}
-
-	return newToken, nil
-}
-
 // JwtMiddleware adalah middleware untuk autentikasi JWT
 func JwtMiddleware() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
@@ -245,6 +237,7 @@ func JwtMiddleware() gin.HandlerFunc {
 		ctx.Set(ContextKeyID, session.ID)
 		ctx.Set(ContextKeyRole, session.Role)
 		ctx.Set(ContextKeyEmail, session.Email)
+		ctx.Set(ContextKeyTwoFactor, session.TwoFactor)
 
 		// Lanjutkan ke handler berikutnya
 		ctx.Next()
@@ -351,6 +344,21 @@ func GetUserEmailFromRedis(ctx *gin.Context) (string, error) {
 	return session.Email, nil
 }
 
+// GetSessionIDFromRedis mengambil ID sesi (refresh token family) dari token yang sedang dipakai
+func GetSessionIDFromRedis(ctx *gin.Context) (string, error) {
+	tokenString, err := getTokenFromHeader(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	session, err := ValidateTokenInRedis(tokenString)
+	if err != nil {
+		return "", fmt.Errorf("failed to get session from redis: %w", err)
+	}
+
+	return session.FamilyID, nil
+}
+
 // GetAllUserDataFromRedis mengambil semua data user dari Redis
 func GetAllUserDataFromRedis(ctx *gin.Context) (uuid.UUID, string, string, error) {
 	tokenString, err := getTokenFromHeader(ctx)
@@ -365,4 +373,3 @@ func GetAllUserDataFromRedis(ctx *gin.Context) (uuid.UUID, string, string, error
 
 	return session.ID, session.Role, session.Email, nil
 }
-
@@ -0,0 +1,225 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"movie-ticket/config"
+	redis_config "movie-ticket/infra/redis"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const defaultRefreshTokenExpiry = 7 * 24 * time.Hour
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected, session revoked")
+)
+
+// RefreshTokenExpiry adalah umur refresh token (env REFRESH_TOKEN_TTL, default 168h)
+func RefreshTokenExpiry() time.Duration {
+	return config.GetDuration("REFRESH_TOKEN_TTL", defaultRefreshTokenExpiry)
+}
+
+// TokenPair adalah pasangan access token (JWT) dan refresh token (opaque)
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    time.Duration
+}
+
+// RefreshSession disimpan di Redis untuk setiap refresh token yang belum dipakai
+type RefreshSession struct {
+	UserID   uuid.UUID `json:"user_id"`
+	FamilyID string    `json:"family_id"`
+}
+
+// Key Redis:
+//
+//	refresh:<sha256>         -> RefreshSession, dihapus saat token dipakai
+//	refresh_used:<sha256>    -> familyID, penanda token yang sudah pernah dipakai
+//	refresh_family:<family>  -> userID, ada selama family masih aktif
+func refreshKey(hash string) string         { return "refresh:" + hash }
+func refreshUsedKey(hash string) string     { return "refresh_used:" + hash }
+func refreshFamilyKey(family string) string { return "refresh_family:" + family }
+
+// Refresh token disimpan dalam bentuk hash supaya isi Redis tidak bisa dipakai langsung
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func newOpaqueToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// IssueTokenPair membuat family baru (satu family = satu login/sesi) beserta token pertamanya
+func IssueTokenPair(id uuid.UUID, role, email string, client ClientInfo) (*TokenPair, error) {
+	ctx := context.Background()
+	now := time.Now()
+
+	session := SessionInfo{
+		ID:         uuid.NewString(),
+		UserID:     id,
+		Device:     client.Device,
+		UserAgent:  client.UserAgent,
+		IP:         client.IP,
+		CreatedAt:  now,
+		LastUsedAt: now,
+		TwoFactor:  client.TwoFactor,
+	}
+
+	if _, err := saveSession(ctx, session, false); err != nil {
+		return nil, fmt.Errorf("failed to save token family to redis: %w", err)
+	}
+
+	return issueInFamily(ctx, id, role, email, session)
+}
+
+// RotateTokenPair membuat pasangan token baru di family yang sama setelah refresh token lama dipakai.
+// Gagal jika family sudah di-revoke di antaranya.
+func RotateTokenPair(refresh *RefreshSession, role, email string, client ClientInfo) (*TokenPair, error) {
+	ctx := context.Background()
+
+	session, err := getSession(ctx, refresh.FamilyID)
+	if err != nil {
+		return nil, fmt.Errorf("redis error: %w", err)
+	}
+	if session == nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	session.LastUsedAt = time.Now()
+	if client.IP != "" {
+		session.IP = client.IP
+	}
+
+	// XX: hanya perpanjang family yang masih ada, family yang sudah di-revoke tidak dihidupkan lagi
+	ok, err := saveSession(ctx, *session, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extend token family: %w", err)
+	}
+	if !ok {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	return issueInFamily(ctx, refresh.UserID, role, email, *session)
+}
+
+func issueInFamily(ctx context.Context, id uuid.UUID, role, email string, session SessionInfo) (*TokenPair, error) {
+	familyID := session.ID
+
+	accessToken, err := CreateToken(id, role, email, familyID, session.TwoFactor)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := newOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	sessionJSON, err := json.Marshal(RefreshSession{UserID: id, FamilyID: familyID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal refresh session: %w", err)
+	}
+
+	if err := redis_config.RedisClient.Set(ctx, refreshKey(hashRefreshToken(refreshToken)), sessionJSON, RefreshTokenExpiry()).Err(); err != nil {
+		return nil, fmt.Errorf("failed to save refresh token to redis: %w", err)
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    AccessTokenExpiry(),
+	}, nil
+}
+
+// ConsumeRefreshToken memakai refresh token tepat satu kali. Token yang dipakai ulang
+// dianggap dicuri: seluruh family-nya di-revoke dan ErrRefreshTokenReused dikembalikan.
+func ConsumeRefreshToken(refreshToken string) (*RefreshSession, error) {
+	ctx := context.Background()
+	hash := hashRefreshToken(refreshToken)
+
+	// GETDEL atomic, dua request bersamaan dengan token yang sama hanya satu yang lolos
+	val, err := redis_config.RedisClient.GetDel(ctx, refreshKey(hash)).Result()
+	if errors.Is(err, redis.Nil) {
+		familyID, usedErr := redis_config.RedisClient.Get(ctx, refreshUsedKey(hash)).Result()
+		if usedErr == nil {
+			if err := RevokeFamily(familyID); err != nil {
+				return nil, err
+			}
+			return nil, ErrRefreshTokenReused
+		}
+		if !errors.Is(usedErr, redis.Nil) {
+			return nil, fmt.Errorf("redis error: %w", usedErr)
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("redis error: %w", err)
+	}
+
+	var session RefreshSession
+	if err := json.Unmarshal([]byte(val), &session); err != nil {
+		return nil, fmt.Errorf("invalid refresh session data: %w", err)
+	}
+
+	if err := redis_config.RedisClient.Set(ctx, refreshUsedKey(hash), session.FamilyID, RefreshTokenExpiry()).Err(); err != nil {
+		return nil, fmt.Errorf("failed to mark refresh token as used: %w", err)
+	}
+
+	active, err := isFamilyActive(ctx, session.FamilyID)
+	if err != nil {
+		return nil, fmt.Errorf("redis error: %w", err)
+	}
+	if !active {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	return &session, nil
+}
+
+// RevokeFamily mematikan semua refresh token dan access token dari satu login
+func RevokeFamily(familyID string) error {
+	if familyID == "" {
+		return nil
+	}
+
+	ctx := context.Background()
+
+	session, err := getSession(ctx, familyID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke token family: %w", err)
+	}
+
+	pipe := redis_config.RedisClient.TxPipeline()
+	pipe.Del(ctx, refreshFamilyKey(familyID))
+	if session != nil {
+		pipe.SRem(ctx, userSessionsKey(session.UserID), familyID)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to revoke token family: %w", err)
+	}
+	return nil
+}
+
+func isFamilyActive(ctx context.Context, familyID string) (bool, error) {
+	n, err := redis_config.RedisClient.Exists(ctx, refreshFamilyKey(familyID)).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"movie-ticket/config"
 	"net/http"
 	"strings"
 
@@ -35,3 +36,28 @@ func GinRoleChecker(roles ...string) gin.HandlerFunc {
 		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Access forbidden for role: " + roleStr})
 	}
 }
+
+// AdminTwoFactorRequired bernilai true jika env ADMIN_REQUIRE_2FA=true
+func AdminTwoFactorRequired() bool {
+	return strings.EqualFold(config.Get("ADMIN_REQUIRE_2FA"), "true")
+}
+
+// RequireAdminTwoFactor menolak admin yang login tanpa 2FA saat ADMIN_REQUIRE_2FA aktif.
+// Dipasang setelah JwtMiddleware di group admin.
+func RequireAdminTwoFactor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !AdminTwoFactorRequired() || c.GetString(ContextKeyRole) != "admin" {
+			c.Next()
+			return
+		}
+
+		if !c.GetBool(ContextKeyTwoFactor) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "Two-factor authentication is required for admin actions, enable it at /2fa/enroll and login again",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,174 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	redis_config "movie-ticket/infra/redis"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+// ClientInfo adalah data perangkat yang dicatat saat login
+type ClientInfo struct {
+	Device    string
+	UserAgent string
+	IP        string
+	// TwoFactor true jika login diselesaikan dengan kode 2FA
+	TwoFactor bool
+}
+
+// SessionInfo adalah satu sesi login, ID-nya sama dengan refresh token family
+type SessionInfo struct {
+	ID         string    `json:"id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Device     string    `json:"device"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	TwoFactor  bool      `json:"two_factor"`
+}
+
+// user_sessions:<userID> -> set berisi ID family milik user
+func userSessionsKey(userID uuid.UUID) string { return "user_sessions:" + userID.String() }
+
+// saveSession menyimpan SessionInfo dan mendaftarkannya di index user.
+// onlyExisting=true tidak akan membuat ulang sesi yang sudah di-revoke.
+func saveSession(ctx context.Context, session SessionInfo, onlyExisting bool) (bool, error) {
+	sessionJSON, err := json.Marshal(session)
+	if err != nil {
+		return false, err
+	}
+
+	if onlyExisting {
+		ok, err := redis_config.RedisClient.SetXX(ctx, refreshFamilyKey(session.ID), sessionJSON, RefreshTokenExpiry()).Result()
+		if err != nil || !ok {
+			return false, err
+		}
+	} else if err := redis_config.RedisClient.Set(ctx, refreshFamilyKey(session.ID), sessionJSON, RefreshTokenExpiry()).Err(); err != nil {
+		return false, err
+	}
+
+	// TTL index ikut sesi yang paling baru dipakai, sesi lain pasti expired lebih dulu
+	pipe := redis_config.RedisClient.TxPipeline()
+	pipe.SAdd(ctx, userSessionsKey(session.UserID), session.ID)
+	pipe.Expire(ctx, userSessionsKey(session.UserID), RefreshTokenExpiry())
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func getSession(ctx context.Context, sessionID string) (*SessionInfo, error) {
+	val, err := redis_config.RedisClient.Get(ctx, refreshFamilyKey(sessionID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var session SessionInfo
+	if err := json.Unmarshal([]byte(val), &session); err != nil {
+		return nil, fmt.Errorf("invalid session data: %w", err)
+	}
+
+	return &session, nil
+}
+
+// ListSessions mengembalikan sesi aktif milik user, urut dari yang terakhir dipakai.
+// ID sesi yang sudah expired dibersihkan dari index.
+func ListSessions(userID uuid.UUID) ([]SessionInfo, error) {
+	ctx := context.Background()
+
+	ids, err := redis_config.RedisClient.SMembers(ctx, userSessionsKey(userID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis error: %w", err)
+	}
+
+	sessions := make([]SessionInfo, 0, len(ids))
+	if len(ids) == 0 {
+		return sessions, nil
+	}
+
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, refreshFamilyKey(id))
+	}
+
+	values, err := redis_config.RedisClient.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis error: %w", err)
+	}
+
+	stale := make([]interface{}, 0)
+	for i, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			stale = append(stale, ids[i])
+			continue
+		}
+
+		var session SessionInfo
+		if err := json.Unmarshal([]byte(raw), &session); err != nil {
+			stale = append(stale, ids[i])
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+
+	if len(stale) > 0 {
+		if err := redis_config.RedisClient.SRem(ctx, userSessionsKey(userID), stale...).Err(); err != nil {
+			fmt.Printf("Warning: failed to prune sessions of user %s: %v\n", userID, err)
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastUsedAt.After(sessions[j].LastUsedAt)
+	})
+
+	return sessions, nil
+}
+
+// RevokeSession me-revoke satu sesi milik user. Sesi milik user lain dianggap tidak ada.
+func RevokeSession(userID uuid.UUID, sessionID string) error {
+	session, err := getSession(context.Background(), sessionID)
+	if err != nil {
+		return fmt.Errorf("redis error: %w", err)
+	}
+	if session == nil || session.UserID != userID {
+		return ErrSessionNotFound
+	}
+
+	return RevokeFamily(sessionID)
+}
+
+// RevokeAllSessions me-revoke semua sesi user, misal saat logout-all atau ganti password
+func RevokeAllSessions(userID uuid.UUID) error {
+	ctx := context.Background()
+
+	ids, err := redis_config.RedisClient.SMembers(ctx, userSessionsKey(userID)).Result()
+	if err != nil {
+		return fmt.Errorf("redis error: %w", err)
+	}
+
+	keys := make([]string, 0, len(ids)+1)
+	for _, id := range ids {
+		keys = append(keys, refreshFamilyKey(id))
+	}
+	keys = append(keys, userSessionsKey(userID))
+
+	if err := redis_config.RedisClient.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return nil
+}
@@ -10,4 +10,14 @@ var (
 	ErrInvalidMovieId   = errors.New("invalid movie id format")
 	ErrInvalidPosterUrl = errors.New("invalid poster URL format")
 	ErrUnauthorizedUser = errors.New("forbidden user")
+	ErrInvalidCursor    = errors.New("invalid pagination cursor")
+	ErrGenreNotFound    = errors.New("genre not found")
+	ErrGenreExists      = errors.New("genre with this name already exists")
+	ErrGenreInUse       = errors.New("genre is still used by movies")
+	ErrInvalidGenreId   = errors.New("invalid genre id format")
+	ErrPersonNotFound   = errors.New("person not found")
+	ErrInvalidPersonId  = errors.New("invalid person id format")
+	ErrPersonHasCredits = errors.New("person still has movie credits")
+	ErrInvalidCredits   = errors.New("invalid movie credits")
+	ErrSchedulesOutside = errors.New("movie has schedules outside the new run window")
 )
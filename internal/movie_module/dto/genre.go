@@ -0,0 +1,18 @@
+package dto
+
+import "github.com/google/uuid"
+
+type GenreRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=50"`
+}
+
+type Genre struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	Slug string    `json:"slug"`
+}
+
+type GenreResponse struct {
+	Genre
+	MovieCount int64 `json:"movie_count"`
+}
@@ -7,21 +7,64 @@ import (
 )
 
 type CreateMovieRequest struct {
-	Title            string `json:"title" validate:"required,min=1,max=200"`
-	Description      string `json:"description" validate:"required,min=1,max=200"`
-	Genre            string `json:"genre" validate:"required,min=1,max=100"`
+	Title       string `json:"title" validate:"required,min=1,max=200"`
+	Description string `json:"description" validate:"required,min=1,max=200"`
+	// Genres berisi slug genre yang sudah terdaftar, minimal satu
+	Genres []string `json:"genres" validate:"omitempty,max=5,dive,required,max=60"`
+	// Deprecated: pakai Genres. Nama genre dipisah koma, dicocokkan lewat slug-nya
+	Genre            string `json:"genre" validate:"omitempty,max=255"`
 	Duration_Minutes int    `json:"duration_minutes" validate:"required,min=1,max=600"`
 	Rating           string `json:"rating" validate:"required,oneof=G PG PG-13 R NC-17"`
 	Poster_Url       string `json:"poster_url" validate:"required,url"`
+	// Release_Date dan End_Date berformat YYYY-MM-DD. Release_Date default hari ini, End_Date kosong berarti belum ditentukan
+	Release_Date string `json:"release_date" validate:"omitempty,datetime=2006-01-02"`
+	End_Date     string `json:"end_date" validate:"omitempty,datetime=2006-01-02"`
 }
 
 type UpdateMovieRequest struct {
-	Title            *string `json:"title,omitempty" validate:"omitempty,min=1,max=200"`
-	Description      *string `json:"description,omitempty" validate:"omitempty,min=1,max=200"`
-	Genre            *string `json:"genre,omitempty" validate:"omitempty,min=1,max=100"`
+	Title       *string `json:"title,omitempty" validate:"omitempty,min=1,max=200"`
+	Description *string `json:"description,omitempty" validate:"omitempty,min=1,max=200"`
+	// Genres nil berarti genre tidak diubah, jika dikirim menggantikan seluruh genre movie
+	Genres []string `json:"genres,omitempty" validate:"omitempty,min=1,max=5,dive,required,max=60"`
+	// Deprecated: pakai Genres
+	Genre            *string `json:"genre,omitempty" validate:"omitempty,min=1,max=255"`
 	Duration_Minutes *int    `json:"duration_minutes,omitempty" validate:"omitempty,min=1,max=600"`
 	Rating           *string `json:"rating,omitempty" validate:"omitempty,oneof=G PG PG-13 R NC-17"`
 	Poster_Url       *string `json:"poster_url,omitempty" validate:"omitempty,url"`
+	Release_Date     *string `json:"release_date,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	// End_Date string kosong berarti masa tayang belum ditentukan
+	End_Date *string `json:"end_date,omitempty" validate:"omitempty,max=10"`
+}
+
+// MovieListQuery adalah query string GET /movie. Jika cursor dikirim, page diabaikan.
+type MovieListQuery struct {
+	Page   int    `form:"page"`
+	Limit  int    `form:"limit"`
+	Cursor string `form:"cursor"`
+	// Genre adalah slug, misal "sci-fi"
+	Genre       string `form:"genre"`
+	Rating      string `form:"rating" binding:"omitempty,oneof=G PG PG-13 R NC-17"`
+	Status      *bool  `form:"status_movie"`
+	MinDuration int    `form:"min_duration" binding:"omitempty,min=1"`
+	MaxDuration int    `form:"max_duration" binding:"omitempty,min=1"`
+	// CreatedFrom dan CreatedTo berformat YYYY-MM-DD atau RFC3339, tanggal saja berarti sepanjang hari itu
+	CreatedFrom string `form:"created_from"`
+	CreatedTo   string `form:"created_to"`
+	// State dihitung dari masa tayang: coming_soon, now_showing, atau ended
+	State string `form:"state" binding:"omitempty,oneof=coming_soon now_showing ended"`
+	// Sort berupa nama kolom, awalan "-" untuk urutan menurun. Default -created_at
+	Sort string `form:"sort" binding:"omitempty,oneof=created_at -created_at title -title duration_minutes -duration_minutes rating -rating"`
+}
+
+type MovieSearchQuery struct {
+	Q      string `form:"q" binding:"required,max=100"`
+	Page   int    `form:"page"`
+	Limit  int    `form:"limit"`
+	Cursor string `form:"cursor"`
+	Genre  string `form:"genre"`
+	Rating string `form:"rating" binding:"omitempty,oneof=G PG PG-13 R NC-17"`
+	Status *bool  `form:"status_movie"`
+	State  string `form:"state" binding:"omitempty,oneof=coming_soon now_showing ended"`
 }
 
 type StatusMovieRequest struct {
@@ -34,15 +77,63 @@ type MovieResponse struct {
 	Title            string    `json:"title"`
 	Description      string    `json:"description"`
 	Genre            string    `json:"genre"`
+	Genres           []Genre   `json:"genres"`
 	Duration_Minutes int       `json:"duration_minutes"`
 	Rating           string    `json:"rating"`
 	Poster_Url       string    `json:"poster_url"`
 	Status           bool      `json:"status_movie"`
+	Release_Date     string    `json:"release_date,omitempty"`
+	End_Date         string    `json:"end_date,omitempty"`
+	State            string    `json:"state"`
 	Created_At       time.Time `json:"created_at"`
 	Updated_At       time.Time `json:"updated_at"`
+	// Credits hanya terisi di detail movie
+	Credits *MovieCredits `json:"credits,omitempty"`
 }
 
 type MoviesResponse struct {
 	Message string `json:"message"`
 	Data    any    `json:"data"`
 }
+
+type MovieListMeta struct {
+	// Page dan TotalPages hanya terisi pada pagination offset (tanpa cursor)
+	Page       int    `json:"page,omitempty"`
+	Limit      int    `json:"limit"`
+	Total      int64  `json:"total"`
+	TotalPages int    `json:"total_pages,omitempty"`
+	Sort       string `json:"sort"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type MovieListLinks struct {
+	Self string `json:"self"`
+	Next string `json:"next,omitempty"`
+	Prev string `json:"prev,omitempty"`
+}
+
+type MovieListResponse struct {
+	Message string           `json:"message"`
+	Data    []*MovieResponse `json:"data"`
+	Meta    MovieListMeta    `json:"meta"`
+	Links   MovieListLinks   `json:"links"`
+}
+
+type MovieHighlight struct {
+	// Title dan Snippet berisi HTML yang sudah di-escape, kata yang cocok dibungkus <mark></mark>
+	Title   string `json:"title"`
+	Snippet string `json:"snippet"`
+}
+
+type MovieSearchItem struct {
+	MovieResponse
+	Score     float64        `json:"score"`
+	Highlight MovieHighlight `json:"highlight"`
+}
+
+type MovieSearchResponse struct {
+	Message string             `json:"message"`
+	Data    []*MovieSearchItem `json:"data"`
+	Meta    MovieListMeta      `json:"meta"`
+	Links   MovieListLinks     `json:"links"`
+}
@@ -0,0 +1,91 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreatePersonRequest struct {
+	Name      string `json:"name" validate:"required,min=1,max=150"`
+	Biography string `json:"biography" validate:"omitempty,max=5000"`
+	// BirthDate berformat YYYY-MM-DD
+	BirthDate string `json:"birth_date" validate:"omitempty,datetime=2006-01-02"`
+	Photo_Url string `json:"photo_url" validate:"omitempty,url"`
+}
+
+type UpdatePersonRequest struct {
+	Name      *string `json:"name,omitempty" validate:"omitempty,min=1,max=150"`
+	Biography *string `json:"biography,omitempty" validate:"omitempty,max=5000"`
+	// BirthDate string kosong menghapus tanggal lahir
+	BirthDate *string `json:"birth_date,omitempty" validate:"omitempty,max=10"`
+	Photo_Url *string `json:"photo_url,omitempty" validate:"omitempty,max=500"`
+}
+
+type PeopleListQuery struct {
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+	Q     string `form:"q" binding:"max=100"`
+}
+
+type PersonResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	Biography  string    `json:"biography"`
+	BirthDate  string    `json:"birth_date,omitempty"`
+	Photo_Url  string    `json:"photo_url"`
+	Created_At time.Time `json:"created_at"`
+	Updated_At time.Time `json:"updated_at"`
+}
+
+type FilmographyItem struct {
+	MovieID      uuid.UUID `json:"movie_id"`
+	Title        string    `json:"title"`
+	Poster_Url   string    `json:"poster_url"`
+	Rating       string    `json:"rating"`
+	Role         string    `json:"role"`
+	Character    string    `json:"character,omitempty"`
+	BillingOrder int       `json:"billing_order"`
+}
+
+type PersonDetailResponse struct {
+	PersonResponse
+	// NowShowing adalah peran di movie yang sedang tayang
+	NowShowing []FilmographyItem `json:"now_showing"`
+}
+
+type PeopleListResponse struct {
+	Message    string            `json:"message"`
+	Data       []*PersonResponse `json:"data"`
+	Page       int               `json:"page"`
+	Limit      int               `json:"limit"`
+	Total      int64             `json:"total"`
+	TotalPages int               `json:"total_pages"`
+}
+
+type CreditRequest struct {
+	PersonID uuid.UUID `json:"person_id" validate:"required"`
+	Role     string    `json:"role" validate:"required,oneof=director writer cast"`
+	// Character hanya untuk role cast
+	Character string `json:"character" validate:"omitempty,max=150"`
+	// BillingOrder 0 berarti mengikuti urutan di request
+	BillingOrder int `json:"billing_order" validate:"min=0"`
+}
+
+type SetCreditsRequest struct {
+	Credits []CreditRequest `json:"credits" validate:"max=200,dive"`
+}
+
+type CreditResponse struct {
+	PersonID     uuid.UUID `json:"person_id"`
+	Name         string    `json:"name"`
+	Photo_Url    string    `json:"photo_url"`
+	Character    string    `json:"character,omitempty"`
+	BillingOrder int       `json:"billing_order"`
+}
+
+type MovieCredits struct {
+	Directors []CreditResponse `json:"directors"`
+	Writers   []CreditResponse `json:"writers"`
+	Cast      []CreditResponse `json:"cast"`
+}
@@ -0,0 +1,31 @@
+package entities
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Genre dipakai bersama oleh banyak movie (relasi many-to-many lewat tabel movie_genres).
+// Slug dibuat dari nama dan dipakai sebagai filter, misal GET /movie?genre=sci-fi
+type Genre struct {
+	ID         uuid.UUID `gorm:"type:uuid; primaryKey" json:"id"`
+	Name       string    `gorm:"type:varchar(50); not null" json:"name"`
+	Slug       string    `gorm:"type:varchar(60); not null; uniqueIndex" json:"slug"`
+	Created_At time.Time `gorm:"autoCreateTime" json:"created_at"`
+	Updated_At time.Time `gorm:"autoCreateTime; autoUpdateTime" json:"updated_at"`
+}
+
+var slugInvalidChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Slugify membuat slug dari nama genre, misal "Sci-Fi & Fantasy" menjadi "sci-fi-fantasy".
+// Aturannya sama dengan migrasi genre lama di infra/postgres.
+func Slugify(name string) string {
+	slug := strings.Trim(slugInvalidChars.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "-"), "-")
+	if len(slug) > 60 {
+		slug = strings.TrimRight(slug[:60], "-")
+	}
+	return slug
+}
@@ -7,14 +7,52 @@ import (
 )
 
 type Movies struct {
-	ID               uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
-	Title            string    `gorm:"type:varchar(200); not null" json:"title" binding:"required"`
-	Description      string    `gorm:"type:text" json:"description" binding:"required"`
-	Genre            string    `gorm:"type:varchar(100)" json:"genre" binding:"required"`
-	Duration_Minutes int       `gorm:"type:int; not null" json:"duration_minutes" binding:"required"`
-	Rating           string    `gorm:"type:varchar(10)" json:"rating" binding:"required"`
-	Poster_Url       string    `gorm:"type:varchar(500)" json:"poster_url" binding:"required"`
-	Status           bool      `gorm:"default=true;" json:"status_movie" binding:"required"`
-	Created_At       time.Time `gorm:"autoCreateTime" json:"created_at"`
-	Updated_At       time.Time `gorm:"autoCreateTime; autoUpdateTime" json:"updated_at"`
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	Title       string    `gorm:"type:varchar(200); not null" json:"title" binding:"required"`
+	Description string    `gorm:"type:text" json:"description" binding:"required"`
+	// Genre berisi nama-nama Genres dipisah koma, diisi otomatis untuk tampilan dan pencarian
+	Genre            string        `gorm:"type:varchar(255)" json:"genre"`
+	Duration_Minutes int           `gorm:"type:int; not null" json:"duration_minutes" binding:"required"`
+	Rating           string        `gorm:"type:varchar(10)" json:"rating" binding:"required"`
+	Poster_Url       string        `gorm:"type:varchar(500)" json:"poster_url" binding:"required"`
+	Status           bool          `gorm:"default=true;" json:"status_movie" binding:"required"`
+	Created_At       time.Time     `gorm:"autoCreateTime" json:"created_at"`
+	Updated_At       time.Time     `gorm:"autoCreateTime; autoUpdateTime" json:"updated_at"`
+	Genres           []Genre       `gorm:"many2many:movie_genres;joinForeignKey:MovieID;joinReferences:GenreID" json:"genres"`
+	Credits          []MovieCredit `gorm:"foreignKey:MovieID" json:"credits,omitempty"`
+	// Release_Date dan End_Date adalah masa tayang (tanggal saja, inklusif). End_Date kosong berarti belum ditentukan.
+	Release_Date *time.Time `gorm:"type:date; index" json:"release_date"`
+	End_Date     *time.Time `gorm:"type:date" json:"end_date"`
+}
+
+type MovieState string
+
+const (
+	MovieComingSoon MovieState = "coming_soon"
+	MovieNowShowing MovieState = "now_showing"
+	MovieEnded      MovieState = "ended"
+)
+
+// DateLayout adalah format tanggal masa tayang, juga dipakai untuk membandingkan tanggal
+// karena kolom date dibaca sebagai tengah malam UTC
+const DateLayout = "2006-01-02"
+
+// StateOn menghitung state movie pada tanggal day (menurut timezone bioskop)
+func (m *Movies) StateOn(day time.Time) MovieState {
+	date := day.Format(DateLayout)
+
+	if m.Release_Date != nil && m.Release_Date.Format(DateLayout) > date {
+		return MovieComingSoon
+	}
+
+	if m.End_Date != nil && m.End_Date.Format(DateLayout) < date {
+		return MovieEnded
+	}
+
+	return MovieNowShowing
+}
+
+// ShowingOn true jika day masih di dalam masa tayang movie
+func (m *Movies) ShowingOn(day time.Time) bool {
+	return m.StateOn(day) == MovieNowShowing
 }
@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func date(value string) *time.Time {
+	t, err := time.Parse(DateLayout, value)
+	if err != nil {
+		panic(err)
+	}
+	return &t
+}
+
+func TestStateOn(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+
+	tests := []struct {
+		name    string
+		release *time.Time
+		end     *time.Time
+		day     time.Time
+		want    MovieState
+	}{
+		{"tanpa masa tayang", nil, nil, time.Date(2026, 3, 10, 12, 0, 0, 0, jakarta), MovieNowShowing},
+		{"sebelum rilis", date("2026-03-11"), nil, time.Date(2026, 3, 10, 23, 59, 0, 0, jakarta), MovieComingSoon},
+		{"hari rilis", date("2026-03-10"), nil, time.Date(2026, 3, 10, 0, 0, 0, 0, jakarta), MovieNowShowing},
+		{"hari terakhir inklusif", date("2026-03-01"), date("2026-03-10"), time.Date(2026, 3, 10, 23, 59, 0, 0, jakarta), MovieNowShowing},
+		{"setelah hari terakhir", date("2026-03-01"), date("2026-03-10"), time.Date(2026, 3, 11, 0, 0, 0, 0, jakarta), MovieEnded},
+		{"hanya end date", nil, date("2026-03-10"), time.Date(2026, 3, 9, 8, 0, 0, 0, jakarta), MovieNowShowing},
+		// tanggal dibaca menurut timezone day, bukan UTC
+		{"dini hari waktu lokal", date("2026-03-10"), nil, time.Date(2026, 3, 10, 1, 0, 0, 0, jakarta), MovieNowShowing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movie := &Movies{Release_Date: tt.release, End_Date: tt.end}
+			if got := movie.StateOn(tt.day); got != tt.want {
+				t.Errorf("StateOn(%s) = %s, want %s", tt.day, got, tt.want)
+			}
+			if got := movie.ShowingOn(tt.day); got != (tt.want == MovieNowShowing) {
+				t.Errorf("ShowingOn(%s) = %v", tt.day, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Person adalah orang yang terlibat di movie (sutradara, penulis, pemeran)
+type Person struct {
+	ID         uuid.UUID  `gorm:"type:uuid; primaryKey" json:"id"`
+	Name       string     `gorm:"type:varchar(150); not null; index" json:"name"`
+	Biography  string     `gorm:"type:text" json:"biography"`
+	BirthDate  *time.Time `gorm:"type:date" json:"birth_date"`
+	Photo_Url  string     `gorm:"type:varchar(500)" json:"photo_url"`
+	Created_At time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	Updated_At time.Time  `gorm:"autoCreateTime; autoUpdateTime" json:"updated_at"`
+}
+
+func (Person) TableName() string {
+	return "people"
+}
+
+type CreditRole string
+
+const (
+	CreditDirector CreditRole = "director"
+	CreditWriter   CreditRole = "writer"
+	CreditCast     CreditRole = "cast"
+)
+
+// MovieCredit menghubungkan Person ke movie dengan perannya. Satu orang bisa punya
+// beberapa peran di movie yang sama (misal sutradara sekaligus penulis).
+// BillingOrder adalah urutan tampil dalam satu peran, dimulai dari 1.
+type MovieCredit struct {
+	ID           uuid.UUID  `gorm:"type:uuid; primaryKey" json:"id"`
+	MovieID      uuid.UUID  `gorm:"type:uuid; not null; uniqueIndex:idx_movie_credit" json:"movie_id"`
+	PersonID     uuid.UUID  `gorm:"type:uuid; not null; uniqueIndex:idx_movie_credit; index" json:"person_id"`
+	Role         CreditRole `gorm:"type:varchar(20); not null; uniqueIndex:idx_movie_credit" json:"role"`
+	Character    string     `gorm:"type:varchar(150)" json:"character"`
+	BillingOrder int        `gorm:"type:int; not null; default:0" json:"billing_order"`
+	Person       Person     `gorm:"foreignKey:PersonID" json:"person"`
+	Created_At   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (MovieCredit) TableName() string {
+	return "movie_credits"
+}
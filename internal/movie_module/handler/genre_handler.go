@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"errors"
+	"movie-ticket/internal/middleware"
+	customerror "movie-ticket/internal/movie_module/custom_error"
+	"movie-ticket/internal/movie_module/dto"
+	"movie-ticket/internal/movie_module/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GenreHandler struct {
+	svc services.GenreService
+}
+
+func NewGenreHandlerAdmin(r *gin.RouterGroup, svc services.GenreService) {
+	h := GenreHandler{svc: svc}
+	r.POST("/genre/create", h.Create)
+	r.PUT("/genre/update/:id", h.Update)
+	r.DELETE("/genre/delete/:id", h.Delete)
+}
+
+func NewGenreHandlerUser(r *gin.RouterGroup, svc services.GenreService) {
+	h := GenreHandler{svc: svc}
+	r.GET("/genres", h.Get)
+}
+
+// Get godoc
+// @Summary Mendapatkan daftar genre
+// @Description Mengambil semua genre beserta jumlah movie-nya, urut berdasarkan nama. Slug dipakai untuk filter GET /movie?genre=
+// @Tags Genres
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Success 200 {object} dto.MoviesResponse "Data genre berhasil diambil"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /genres [get]
+// @Security BearerAuth
+func (h *GenreHandler) Get(c *gin.Context) {
+	genres, err := h.svc.GetGenres()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MoviesResponse{Message: "successfully retrieved the data", Data: genres})
+}
+
+// Create godoc
+// @Summary Membuat genre baru (Admin only)
+// @Description Membuat genre baru, slug dibuat otomatis dari nama. Hanya admin yang dapat mengakses endpoint ini
+// @Tags Genres
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param request body dto.GenreRequest true "Genre data"
+// @Success 201 {object} map[string]interface{} "Genre created successfully"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - User bukan admin"
+// @Failure 409 {object} map[string]interface{} "Conflict - Genre sudah ada"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /admin/genre/create [post]
+// @Security BearerAuth
+func (h *GenreHandler) Create(c *gin.Context) {
+	userRole, err := middleware.GetUserRoleFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed Get session from redis"})
+		return
+	}
+
+	var req dto.GenreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	genre, err := h.svc.CreateGenre(userRole, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": genre})
+}
+
+// Update godoc
+// @Summary Ubah nama genre (Admin only)
+// @Description Mengganti nama genre. Slug ikut berubah dan teks genre di semua movie terkait diperbarui. Hanya admin yang dapat mengakses endpoint ini
+// @Tags Genres
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Genre ID" format(uuid)
+// @Param request body dto.GenreRequest true "Genre data"
+// @Success 200 {object} dto.MoviesResponse "Genre updated successfully"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid input atau genre ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - User bukan admin"
+// @Failure 404 {object} map[string]interface{} "Not Found - Genre tidak ditemukan"
+// @Failure 409 {object} map[string]interface{} "Conflict - Nama genre sudah dipakai"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /admin/genre/update/{id} [put]
+// @Security BearerAuth
+func (h *GenreHandler) Update(c *gin.Context) {
+	userRole, err := middleware.GetUserRoleFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed Get session from redis"})
+		return
+	}
+
+	var req dto.GenreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	genre, err := h.svc.UpdateGenre(userRole, c.Param("id"), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MoviesResponse{Message: "successfully updated data", Data: genre})
+}
+
+// Delete godoc
+// @Summary Hapus genre (Admin only)
+// @Description Menghapus genre yang tidak dipakai movie manapun. Hanya admin yang dapat mengakses endpoint ini
+// @Tags Genres
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Genre ID" format(uuid)
+// @Success 200 {object} map[string]interface{} "Genre deleted successfully"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid genre ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - User bukan admin"
+// @Failure 404 {object} map[string]interface{} "Not Found - Genre tidak ditemukan"
+// @Failure 409 {object} map[string]interface{} "Conflict - Genre masih dipakai movie"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /admin/genre/delete/{id} [delete]
+// @Security BearerAuth
+func (h *GenreHandler) Delete(c *gin.Context) {
+	userRole, err := middleware.GetUserRoleFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed Get session from redis"})
+		return
+	}
+
+	if err := h.svc.DeleteGenre(userRole, c.Param("id")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "successfully deleted data"})
+}
+
+func (h *GenreHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, customerror.ErrUnauthorizedUser):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, customerror.ErrInvalidInput), errors.Is(err, customerror.ErrInvalidGenreId):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, customerror.ErrGenreNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, customerror.ErrGenreExists), errors.Is(err, customerror.ErrGenreInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
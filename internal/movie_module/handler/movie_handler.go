@@ -22,24 +22,26 @@ func NewMovieHandlerAdmin(r *gin.RouterGroup, svc services.MoviesService) {
 	r.PUT("/movie/update/:id", h.Update)
 	r.DELETE("/movie/delete/:id", h.Delete)
 	r.PATCH("/movie/:id/status", h.PatchStatus)
+	r.PUT("/movie/:id/credits", h.SetCredits)
 }
 
 func NewMoviehandlerUser(r *gin.RouterGroup, svc services.MoviesService) {
 	h := MovieHandler{svc: svc}
 	r.GET("/movie", h.Get)
+	r.GET("/movie/search", h.Search)
 	r.GET("/movie/:id", h.GetById)
 }
 
 // Create godoc
 // @Summary Membuat movie baru (Admin only)
-// @Description Membuat movie baru dengan informasi lengkap. Hanya admin yang dapat mengakses endpoint ini
+// @Description Membuat movie baru dengan informasi lengkap. Genre dikirim sebagai daftar slug dari GET /genres. Masa tayang diisi lewat release_date (default hari ini) dan end_date (opsional). Hanya admin yang dapat mengakses endpoint ini
 // @Tags Movies
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer token" default(Bearer <token>)
 // @Param request body dto.CreateMovieRequest true "Movie creation data"
 // @Success 201 {object} map[string]interface{} "Movie created successfully"
-// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid input, session, atau poster URL"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid input, session, poster URL, atau genre tidak terdaftar"
 // @Failure 401 {object} map[string]interface{} "Unauthorized - User bukan admin"
 // @Failure 409 {object} map[string]interface{} "Conflict - Movie sudah ada"
 // @Failure 500 {object} map[string]interface{} "Internal Server Error"
@@ -65,7 +67,7 @@ func (h *MovieHandler) Create(c *gin.Context) {
 			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 		case errors.Is(err, customerror.ErrInvalidInput):
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		case errors.Is(err, customerror.ErrInvalidPosterUrl):
+		case errors.Is(err, customerror.ErrInvalidPosterUrl), errors.Is(err, customerror.ErrGenreNotFound):
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		case errors.Is(err, customerror.ErrUnauthorizedUser):
 			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
@@ -79,51 +81,101 @@ func (h *MovieHandler) Create(c *gin.Context) {
 }
 
 // Get godoc
-// @Summary Mendapatkan daftar movie dengan pagination
-// @Description Mengambil daftar semua movie yang tersedia dengan dukungan pagination
+// @Summary Mendapatkan daftar movie dengan pagination, filter, dan sort
+// @Description Mengambil daftar movie dengan pagination offset (page) atau cursor. Jika cursor dikirim, page diabaikan dan filter yang sama harus tetap dikirim (link next sudah menyertakannya)
 // @Tags Movies
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer token" default(Bearer <token>)
 // @Param page query int false "Nomor halaman" default(1) minimum(1)
 // @Param limit query int false "Jumlah data per halaman" default(10) minimum(1) maximum(100)
-// @Success 200 {object} dto.MoviesResponse "Data movie berhasil diambil"
-// @Failure 404 {object} map[string]interface{} "Movie tidak ditemukan"
+// @Param cursor query string false "Cursor dari meta.next_cursor halaman sebelumnya"
+// @Param genre query string false "Filter slug genre, lihat GET /genres"
+// @Param rating query string false "Filter rating" Enums(G, PG, PG-13, R, NC-17)
+// @Param status_movie query bool false "Filter status movie"
+// @Param min_duration query int false "Durasi minimal (menit)"
+// @Param max_duration query int false "Durasi maksimal (menit)"
+// @Param created_from query string false "Dibuat sejak (YYYY-MM-DD atau RFC3339)"
+// @Param created_to query string false "Dibuat sampai (YYYY-MM-DD atau RFC3339)"
+// @Param state query string false "Filter state masa tayang" Enums(coming_soon, now_showing, ended)
+// @Param sort query string false "Urutan, awalan - untuk menurun" Enums(created_at, -created_at, title, -title, duration_minutes, -duration_minutes, rating, -rating) default(-created_at)
+// @Success 200 {object} dto.MovieListResponse "Data movie berhasil diambil"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Query atau cursor tidak valid"
 // @Failure 500 {object} map[string]interface{} "Internal Server Error"
 // @Router /movie [get]
 // @Security BearerAuth
 func (h *MovieHandler) Get(c *gin.Context) {
-	pageStr := c.DefaultQuery("page", "1")
-	limitStr := c.DefaultQuery("limit", "10")
-
-	page, err := strconv.Atoi(pageStr)
-	if err != nil {
-		page = 1
+	var query dto.MovieListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	limit, err := strconv.Atoi(limitStr)
+	movies, err := h.svc.GetMovies(&query)
+
 	if err != nil {
-		limit = 10
+		switch {
+		case errors.Is(err, customerror.ErrInvalidInput), errors.Is(err, customerror.ErrInvalidCursor):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
 	}
 
-	movies, err := h.svc.GetMovies(page, limit)
+	movies.Message = "successfully retrieved the data"
+	movies.Links = movieListLinks(c, &movies.Meta)
+
+	c.JSON(http.StatusOK, movies)
+}
+
+// Search godoc
+// @Summary Cari movie
+// @Description Pencarian full-text di judul, genre, dan deskripsi, diurutkan berdasarkan relevansi. Setiap kata dicocokkan sebagai awalan kata dan judul yang salah ketik tetap ditemukan. Kata yang cocok pada highlight dibungkus <mark></mark>. Pagination sama dengan GET /movie
+// @Tags Movies
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param q query string true "Kata kunci"
+// @Param page query int false "Nomor halaman" default(1) minimum(1)
+// @Param limit query int false "Jumlah data per halaman" default(10) minimum(1) maximum(100)
+// @Param cursor query string false "Cursor dari meta.next_cursor halaman sebelumnya"
+// @Param genre query string false "Filter slug genre, lihat GET /genres"
+// @Param rating query string false "Filter rating" Enums(G, PG, PG-13, R, NC-17)
+// @Param status_movie query bool false "Filter status movie"
+// @Param state query string false "Filter state masa tayang" Enums(coming_soon, now_showing, ended)
+// @Success 200 {object} dto.MovieSearchResponse "Hasil pencarian"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Kata kunci, query, atau cursor tidak valid"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /movie/search [get]
+// @Security BearerAuth
+func (h *MovieHandler) Search(c *gin.Context) {
+	var query dto.MovieSearchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
+	movies, err := h.svc.SearchMovies(&query)
 	if err != nil {
 		switch {
-		case errors.Is(err, customerror.ErrMovieNotFound):
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, customerror.ErrInvalidInput), errors.Is(err, customerror.ErrInvalidCursor):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.MoviesResponse{Message: "successfully retrieved the data", Data: movies})
+	movies.Message = "successfully retrieved the data"
+	movies.Links = movieListLinks(c, &movies.Meta)
+
+	c.JSON(http.StatusOK, movies)
 }
 
 // GetById godoc
 // @Summary Mendapatkan detail movie berdasarkan ID
-// @Description Mengambil informasi lengkap movie berdasarkan ID yang diberikan
+// @Description Mengambil informasi lengkap movie berdasarkan ID yang diberikan, termasuk sutradara, penulis, dan pemeran (credits)
 // @Tags Movies
 // @Accept json
 // @Produce json
@@ -156,7 +208,7 @@ func (h *MovieHandler) GetById(c *gin.Context) {
 
 // Update godoc
 // @Summary Update movie (Admin only)
-// @Description Mengupdate informasi movie yang sudah ada. Hanya admin yang dapat mengakses endpoint ini
+// @Description Mengupdate informasi movie yang sudah ada. end_date kosong berarti masa tayang belum ditentukan. Masa tayang ditolak jika ada jadwal di luar masa tayang baru. Hanya admin yang dapat mengakses endpoint ini
 // @Tags Movies
 // @Accept json
 // @Produce json
@@ -164,9 +216,9 @@ func (h *MovieHandler) GetById(c *gin.Context) {
 // @Param id path string true "Movie ID" format(uuid)
 // @Param request body dto.UpdateMovieRequest true "Movie update data"
 // @Success 201 {object} dto.MoviesResponse "Movie updated successfully"
-// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid input, session, poster URL, atau movie ID"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid input, session, poster URL, genre tidak terdaftar, atau movie ID"
 // @Failure 401 {object} map[string]interface{} "Unauthorized - User bukan admin"
-// @Failure 409 {object} map[string]interface{} "Conflict - Movie sudah ada"
+// @Failure 409 {object} map[string]interface{} "Conflict - Movie sudah ada, atau masih ada jadwal di luar masa tayang baru"
 // @Failure 500 {object} map[string]interface{} "Internal Server Error"
 // @Router /admin/movie/update/{id} [put]
 // @Security BearerAuth
@@ -188,11 +240,11 @@ func (h *MovieHandler) Update(c *gin.Context) {
 	updateMovie, err := h.svc.UpdateMovie(userRole, idParam, &req)
 	if err != nil {
 		switch {
-		case errors.Is(err, customerror.ErrMovieExists):
+		case errors.Is(err, customerror.ErrMovieExists), errors.Is(err, customerror.ErrSchedulesOutside):
 			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 		case errors.Is(err, customerror.ErrInvalidInput):
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		case errors.Is(err, customerror.ErrInvalidPosterUrl):
+		case errors.Is(err, customerror.ErrInvalidPosterUrl), errors.Is(err, customerror.ErrGenreNotFound):
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		case errors.Is(err, customerror.ErrInvalidMovieId):
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -251,7 +303,7 @@ func (h *MovieHandler) Delete(c *gin.Context) {
 
 // PatchStatus godoc
 // @Summary Update status movie (Admin only)
-// @Description Mengubah status movie (aktif/non-aktif) berdasarkan ID. Hanya admin yang dapat mengakses endpoint ini
+// @Description Mengubah status movie (aktif/non-aktif) berdasarkan ID. State tayang (coming_soon, now_showing, ended) tidak dipengaruhi status, melainkan dihitung dari release_date dan end_date. Hanya admin yang dapat mengakses endpoint ini
 // @Tags Movies
 // @Accept json
 // @Produce json
@@ -304,3 +356,80 @@ func (h *MovieHandler) PatchStatus(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Successfully enabled the film"})
 }
+
+// SetCredits godoc
+// @Summary Atur credits movie (Admin only)
+// @Description Mengganti seluruh sutradara, penulis, dan pemeran movie. Character hanya dipakai untuk role cast, billing_order 0 diisi sesuai urutan di request. Hanya admin yang dapat mengakses endpoint ini
+// @Tags Movies
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Movie ID" format(uuid)
+// @Param request body dto.SetCreditsRequest true "Movie credits"
+// @Success 200 {object} dto.MoviesResponse "Credits berhasil diatur"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid request body, movie ID, credits, atau session failed"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - User bukan admin"
+// @Failure 404 {object} map[string]interface{} "Not Found - Movie atau person tidak ditemukan"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /admin/movie/{id}/credits [put]
+// @Security BearerAuth
+func (h *MovieHandler) SetCredits(c *gin.Context) {
+	userRole, err := middleware.GetUserRoleFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed Get session from redis"})
+		return
+	}
+
+	var req dto.SetCreditsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	movie, err := h.svc.SetCredits(userRole, c.Param("id"), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, customerror.ErrMovieNotFound), errors.Is(err, customerror.ErrPersonNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, customerror.ErrInvalidMovieId), errors.Is(err, customerror.ErrInvalidCredits), errors.Is(err, customerror.ErrInvalidInput):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, customerror.ErrUnauthorizedUser):
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MoviesResponse{Message: "successfully updated credits", Data: movie})
+}
+
+// movieListLinks membuat link self/next/prev dari URL request dengan query yang sama
+func movieListLinks(c *gin.Context, meta *dto.MovieListMeta) dto.MovieListLinks {
+	link := func(set map[string]string) string {
+		u := *c.Request.URL
+		q := u.Query()
+		for key, value := range set {
+			if value == "" {
+				q.Del(key)
+			} else {
+				q.Set(key, value)
+			}
+		}
+		u.RawQuery = q.Encode()
+		return u.RequestURI()
+	}
+
+	links := dto.MovieListLinks{Self: c.Request.URL.RequestURI()}
+
+	if meta.NextCursor != "" {
+		links.Next = link(map[string]string{"cursor": meta.NextCursor, "page": ""})
+	}
+
+	// prev hanya tersedia di pagination offset, cursor hanya bisa maju
+	if meta.Page > 1 {
+		links.Prev = link(map[string]string{"page": strconv.Itoa(meta.Page - 1)})
+	}
+
+	return links
+}
@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"errors"
+	"movie-ticket/internal/middleware"
+	customerror "movie-ticket/internal/movie_module/custom_error"
+	"movie-ticket/internal/movie_module/dto"
+	"movie-ticket/internal/movie_module/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PersonHandler struct {
+	svc services.PersonService
+}
+
+func NewPersonHandlerAdmin(r *gin.RouterGroup, svc services.PersonService) {
+	h := PersonHandler{svc: svc}
+	r.POST("/people/create", h.Create)
+	r.PUT("/people/update/:id", h.Update)
+	r.DELETE("/people/delete/:id", h.Delete)
+}
+
+func NewPersonHandlerUser(r *gin.RouterGroup, svc services.PersonService) {
+	h := PersonHandler{svc: svc}
+	r.GET("/people", h.Get)
+	r.GET("/people/:id", h.GetById)
+}
+
+// Get godoc
+// @Summary Mendapatkan daftar orang (sutradara, penulis, pemeran)
+// @Description Mengambil daftar orang urut berdasarkan nama, bisa dicari berdasarkan nama
+// @Tags People
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param q query string false "Cari berdasarkan nama"
+// @Param page query int false "Nomor halaman" default(1) minimum(1)
+// @Param limit query int false "Jumlah data per halaman" default(10) minimum(1) maximum(100)
+// @Success 200 {object} dto.PeopleListResponse "Data berhasil diambil"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Query tidak valid"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /people [get]
+// @Security BearerAuth
+func (h *PersonHandler) Get(c *gin.Context) {
+	var query dto.PeopleListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	people, err := h.svc.GetPeople(&query)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	people.Message = "successfully retrieved the data"
+	c.JSON(http.StatusOK, people)
+}
+
+// GetById godoc
+// @Summary Mendapatkan detail orang
+// @Description Mengambil profil orang beserta filmografi yang sedang tayang (state now_showing)
+// @Tags People
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Person ID" format(uuid)
+// @Success 200 {object} dto.MoviesResponse "Detail berhasil diambil"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid person ID"
+// @Failure 404 {object} map[string]interface{} "Not Found - Person tidak ditemukan"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /people/{id} [get]
+// @Security BearerAuth
+func (h *PersonHandler) GetById(c *gin.Context) {
+	person, err := h.svc.GetPersonById(c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MoviesResponse{Message: "successfully retrieved the data", Data: person})
+}
+
+// Create godoc
+// @Summary Menambah orang baru (Admin only)
+// @Description Menambah sutradara, penulis, atau pemeran. Hanya admin yang dapat mengakses endpoint ini
+// @Tags People
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param request body dto.CreatePersonRequest true "Person data"
+// @Success 201 {object} map[string]interface{} "Person created successfully"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - User bukan admin"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /admin/people/create [post]
+// @Security BearerAuth
+func (h *PersonHandler) Create(c *gin.Context) {
+	userRole, err := middleware.GetUserRoleFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed Get session from redis"})
+		return
+	}
+
+	var req dto.CreatePersonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	person, err := h.svc.CreatePerson(userRole, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": person})
+}
+
+// Update godoc
+// @Summary Update data orang (Admin only)
+// @Description Mengubah sebagian data orang, field yang tidak dikirim tidak diubah. birth_date kosong menghapus tanggal lahir. Hanya admin yang dapat mengakses endpoint ini
+// @Tags People
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Person ID" format(uuid)
+// @Param request body dto.UpdatePersonRequest true "Person update data"
+// @Success 200 {object} dto.MoviesResponse "Person updated successfully"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid input atau person ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - User bukan admin"
+// @Failure 404 {object} map[string]interface{} "Not Found - Person tidak ditemukan"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /admin/people/update/{id} [put]
+// @Security BearerAuth
+func (h *PersonHandler) Update(c *gin.Context) {
+	userRole, err := middleware.GetUserRoleFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed Get session from redis"})
+		return
+	}
+
+	var req dto.UpdatePersonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+
+	person, err := h.svc.UpdatePerson(userRole, c.Param("id"), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.MoviesResponse{Message: "successfully updated data", Data: person})
+}
+
+// Delete godoc
+// @Summary Hapus orang (Admin only)
+// @Description Menghapus orang yang tidak punya credit di movie manapun. Hanya admin yang dapat mengakses endpoint ini
+// @Tags People
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Person ID" format(uuid)
+// @Success 200 {object} map[string]interface{} "Person deleted successfully"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid person ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - User bukan admin"
+// @Failure 404 {object} map[string]interface{} "Not Found - Person tidak ditemukan"
+// @Failure 409 {object} map[string]interface{} "Conflict - Person masih punya credit di movie"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /admin/people/delete/{id} [delete]
+// @Security BearerAuth
+func (h *PersonHandler) Delete(c *gin.Context) {
+	userRole, err := middleware.GetUserRoleFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed Get session from redis"})
+		return
+	}
+
+	if err := h.svc.DeletePerson(userRole, c.Param("id")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "successfully deleted data"})
+}
+
+func (h *PersonHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, customerror.ErrUnauthorizedUser):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, customerror.ErrInvalidInput), errors.Is(err, customerror.ErrInvalidPersonId):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, customerror.ErrPersonNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, customerror.ErrPersonHasCredits):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
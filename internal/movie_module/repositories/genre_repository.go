@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"errors"
+	"movie-ticket/infra/postgres"
+	"movie-ticket/internal/movie_module/entities"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type GenreRepository interface {
+	GetAll() ([]GenreWithCount, error)
+	GetByID(id uuid.UUID) (*entities.Genre, error)
+	GetBySlug(slug string) (*entities.Genre, error)
+	GetBySlugs(slugs []string) ([]entities.Genre, error)
+	Create(genre *entities.Genre) error
+	Update(genre *entities.Genre) error
+	Delete(id uuid.UUID) error
+	CountMovies(id uuid.UUID) (int64, error)
+}
+
+type GenreWithCount struct {
+	entities.Genre
+	MovieCount int64
+}
+
+type genreRepo struct{}
+
+func NewGenreRepo() GenreRepository {
+	return &genreRepo{}
+}
+
+func (r *genreRepo) GetAll() ([]GenreWithCount, error) {
+	var genres []GenreWithCount
+
+	err := postgres.DB.Model(&entities.Genre{}).
+		Select("genres.*, COUNT(mg.movie_id) AS movie_count").
+		Joins("LEFT JOIN movie_genres mg ON mg.genre_id = genres.id").
+		Group("genres.id").
+		Order("genres.name ASC").
+		Scan(&genres).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return genres, nil
+}
+
+func (r *genreRepo) GetByID(id uuid.UUID) (*entities.Genre, error) {
+	var genre entities.Genre
+
+	err := postgres.DB.Where("id = ?", id).First(&genre).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &genre, nil
+}
+
+func (r *genreRepo) GetBySlug(slug string) (*entities.Genre, error) {
+	var genre entities.Genre
+
+	err := postgres.DB.Where("slug = ?", slug).First(&genre).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &genre, nil
+}
+
+func (r *genreRepo) GetBySlugs(slugs []string) ([]entities.Genre, error) {
+	var genres []entities.Genre
+
+	if err := postgres.DB.Where("slug IN ?", slugs).Order("name ASC").Find(&genres).Error; err != nil {
+		return nil, err
+	}
+
+	return genres, nil
+}
+
+func (r *genreRepo) Create(genre *entities.Genre) error {
+	return postgres.DB.Create(genre).Error
+}
+
+// Update menyimpan nama/slug baru dan memperbarui teks genre di semua movie yang memakainya
+func (r *genreRepo) Update(genre *entities.Genre) error {
+	return postgres.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entities.Genre{}).
+			Where("id = ?", genre.ID).
+			Updates(map[string]interface{}{
+				"name":       genre.Name,
+				"slug":       genre.Slug,
+				"updated_at": genre.Updated_At,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Exec(`UPDATE movies m SET genre = sub.names
+			FROM (
+				SELECT mg.movie_id, string_agg(g.name, ', ' ORDER BY g.name) AS names
+				FROM movie_genres mg JOIN genres g ON g.id = mg.genre_id
+				WHERE mg.movie_id IN (SELECT movie_id FROM movie_genres WHERE genre_id = ?)
+				GROUP BY mg.movie_id
+			) sub
+			WHERE m.id = sub.movie_id`, genre.ID).Error
+	})
+}
+
+func (r *genreRepo) Delete(id uuid.UUID) error {
+	return postgres.DB.Delete(&entities.Genre{}, "id = ?", id).Error
+}
+
+func (r *genreRepo) CountMovies(id uuid.UUID) (int64, error) {
+	var count int64
+	err := postgres.DB.Table("movie_genres").Where("genre_id = ?", id).Count(&count).Error
+	return count, err
+}
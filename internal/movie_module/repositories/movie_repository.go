@@ -3,8 +3,11 @@ package repositories
 import (
 	"errors"
 	"fmt"
+	"html"
 	"movie-ticket/infra/postgres"
 	"movie-ticket/internal/movie_module/entities"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -12,14 +15,80 @@ import (
 
 type MovieRepository interface {
 	CreateMovies(input *entities.Movies) error
-	GetMovies() ([]entities.Movies, error)
+	GetMovies(filter MovieFilter) ([]entities.Movies, int64, error)
+	SearchMovies(search MovieSearch) ([]MovieSearchResult, int64, error)
+	ReplaceCredits(movieID uuid.UUID, credits []entities.MovieCredit) error
 	GetByTitle(input string) ([]entities.Movies, error)
 	GetMovieById(id uuid.UUID) (*entities.Movies, error)
 	UpdateMovies(id uuid.UUID, input *entities.Movies) error
+	SchedulesOutsideWindow(id uuid.UUID, releaseDate, endDate *time.Time, timezone string) ([]ScheduleOutsideWindow, error)
 	UpdateStatus(id uuid.UUID, status bool) error
 	DeleteMovie(id uuid.UUID) error
 }
 
+// MovieFilter untuk daftar movie, field kosong berarti tidak difilter
+type MovieFilter struct {
+	Genre       string
+	Rating      string
+	Status      *bool
+	MinDuration int
+	MaxDuration int
+	CreatedFrom *time.Time
+	// CreatedTo eksklusif
+	CreatedTo *time.Time
+	// State dihitung dari masa tayang terhadap Today (YYYY-MM-DD di timezone bioskop)
+	State entities.MovieState
+	Today string
+
+	// SortColumn harus salah satu dari MovieSortColumns
+	SortColumn string
+	SortDesc   bool
+
+	Offset int
+	Limit  int
+	// After dipakai untuk pagination cursor, Offset diabaikan jika terisi
+	After *MovieCursor
+}
+
+// MovieCursor adalah posisi item terakhir halaman sebelumnya: nilai kolom sort dan ID sebagai tie-breaker
+type MovieCursor struct {
+	Value interface{}
+	ID    uuid.UUID
+}
+
+// MovieSearch untuk pencarian full-text. Sort di MovieFilter diabaikan karena hasil
+// selalu diurutkan berdasarkan relevansi, After.Value berisi rank item terakhir.
+type MovieSearch struct {
+	MovieFilter
+	// TSQuery sudah dalam sintaks to_tsquery, misal "aveng:* & endgame:*"
+	TSQuery string
+	// Text adalah kata kunci asli untuk pencocokan trigram (toleran typo)
+	Text string
+}
+
+type MovieSearchResult struct {
+	entities.Movies
+	Rank           float64
+	TitleHighlight string
+	Snippet        string
+}
+
+// ScheduleOutsideWindow adalah jadwal movie yang tidak masuk masa tayang baru
+type ScheduleOutsideWindow struct {
+	ID        uuid.UUID
+	StartTime time.Time
+	// SoldSeats adalah jumlah kursi dari reservasi PAID
+	SoldSeats int64
+}
+
+// MovieSortColumns adalah kolom yang boleh dipakai untuk sort
+var MovieSortColumns = map[string]bool{
+	"created_at":       true,
+	"title":            true,
+	"duration_minutes": true,
+	"rating":           true,
+}
+
 type movieRepo struct{}
 
 func NewMovieRepo() MovieRepository {
@@ -27,22 +96,52 @@ func NewMovieRepo() MovieRepository {
 }
 
 func (r *movieRepo) CreateMovies(input *entities.Movies) error {
-	if err := postgres.DB.Create(input).Error; err != nil {
+	// Genre sudah ada, yang dibuat hanya baris relasi di movie_genres
+	if err := postgres.DB.Omit("Genres.*").Create(input).Error; err != nil {
 		return fmt.Errorf("failed to create movie: %w", err)
 	}
 	return nil
 }
 
-func (r *movieRepo) GetMovies() ([]entities.Movies, error) {
-	var movies []entities.Movies
+func (r *movieRepo) GetMovies(filter MovieFilter) ([]entities.Movies, int64, error) {
+	// Nama kolom masuk ke SQL apa adanya, jadi harus dari daftar yang diizinkan
+	if !MovieSortColumns[filter.SortColumn] {
+		return nil, 0, fmt.Errorf("invalid sort column: %s", filter.SortColumn)
+	}
 
-	err := postgres.DB.Find(&movies).Error
+	query := applyMovieFilter(postgres.DB.Model(&entities.Movies{}), filter)
 
-	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, nil
+	// Total dihitung sebelum kondisi cursor supaya sama untuk setiap halaman
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
 	}
 
-	return movies, nil
+	direction, compare := "ASC", ">"
+	if filter.SortDesc {
+		direction, compare = "DESC", "<"
+	}
+
+	if filter.After != nil {
+		query = query.Where(
+			fmt.Sprintf("(%s, id) %s (?, ?)", filter.SortColumn, compare),
+			filter.After.Value, filter.After.ID,
+		)
+	} else {
+		query = query.Offset(filter.Offset)
+	}
+
+	var movies []entities.Movies
+	err := query.
+		Preload("Genres", orderGenres).
+		Order(fmt.Sprintf("%s %s, id %s", filter.SortColumn, direction, direction)).
+		Limit(filter.Limit).
+		Find(&movies).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return movies, total, nil
 }
 
 func (r *movieRepo) GetByTitle(input string) ([]entities.Movies, error) {
@@ -56,15 +155,24 @@ func (r *movieRepo) GetByTitle(input string) ([]entities.Movies, error) {
 }
 
 func (r *movieRepo) GetMovieById(id uuid.UUID) (*entities.Movies, error) {
-	var movies *entities.Movies
-
-	err := postgres.DB.Where("id = ?", id).Find(&movies).Error
+	var movie entities.Movies
 
+	err := postgres.DB.
+		Preload("Genres", orderGenres).
+		Preload("Credits", func(db *gorm.DB) *gorm.DB {
+			return db.Order("billing_order ASC, created_at ASC")
+		}).
+		Preload("Credits.Person").
+		Where("id = ?", id).
+		First(&movie).Error
 	if err != nil {
-		return nil, errors.New("no available movies")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
 	}
 
-	return movies, nil
+	return &movie, nil
 }
 
 func (r *movieRepo) UpdateMovies(id uuid.UUID, input *entities.Movies) error {
@@ -75,25 +183,91 @@ func (r *movieRepo) UpdateMovies(id uuid.UUID, input *entities.Movies) error {
 		"duration_minutes": input.Duration_Minutes,
 		"rating":           input.Rating,
 		"poster_url":       input.Poster_Url,
+		"release_date":     input.Release_Date,
+		"end_date":         input.End_Date,
 	}
 
-	result := postgres.DB.Model(&entities.Movies{}).
-		Where("id = ?", id).
-		Updates(updates)
+	return postgres.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.Movies{}).
+			Where("id = ?", id).
+			Updates(updates)
 
-	if result.Error != nil {
-		return result.Error
-	}
+		if result.Error != nil {
+			return result.Error
+		}
 
-	if result.RowsAffected == 0 {
-		return errors.New("failed when updating data")
-	}
+		if result.RowsAffected == 0 {
+			return errors.New("failed when updating data")
+		}
 
-	return nil
+		// Genres nil berarti genre tidak diubah
+		if input.Genres == nil {
+			return nil
+		}
+
+		return tx.Model(&entities.Movies{ID: id}).Omit("Genres.*").Association("Genres").Replace(input.Genres)
+	})
 }
 
 func (r *movieRepo) DeleteMovie(id uuid.UUID) error {
-	return postgres.DB.Delete(&entities.Movies{}, "id = ?", id).Error
+	return postgres.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM movie_genres WHERE movie_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&entities.MovieCredit{}, "movie_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entities.Movies{}, "id = ?", id).Error
+	})
+}
+
+// ReplaceCredits mengganti seluruh credit movie dalam satu transaksi
+func (r *movieRepo) ReplaceCredits(movieID uuid.UUID, credits []entities.MovieCredit) error {
+	return postgres.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&entities.MovieCredit{}, "movie_id = ?", movieID).Error; err != nil {
+			return err
+		}
+
+		if len(credits) == 0 {
+			return nil
+		}
+
+		return tx.Omit("Person").Create(&credits).Error
+	})
+}
+
+// SchedulesOutsideWindow mencari jadwal movie yang tanggal tayangnya (di timezone bioskop) sebelum
+// releaseDate atau setelah endDate. Tanggal nil berarti tidak dibatasi di sisi itu.
+func (r *movieRepo) SchedulesOutsideWindow(id uuid.UUID, releaseDate, endDate *time.Time, timezone string) ([]ScheduleOutsideWindow, error) {
+	var schedules []ScheduleOutsideWindow
+	if releaseDate == nil && endDate == nil {
+		return schedules, nil
+	}
+
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	if releaseDate != nil {
+		conditions = append(conditions, "(s.start_time AT TIME ZONE ?)::date < ?")
+		args = append(args, timezone, releaseDate.Format(entities.DateLayout))
+	}
+	if endDate != nil {
+		conditions = append(conditions, "(s.start_time AT TIME ZONE ?)::date > ?")
+		args = append(args, timezone, endDate.Format(entities.DateLayout))
+	}
+
+	err := postgres.DB.Table("schedules s").
+		Select("s.id, s.start_time, count(rs.id) FILTER (WHERE r.status = ?) AS sold_seats", "PAID").
+		Joins("LEFT JOIN reservation_seats rs ON rs.schedule_id = s.id AND rs.released_at IS NULL").
+		Joins("LEFT JOIN reservations r ON r.id = rs.reservation_id").
+		Where("s.movie_id = ?", id).
+		Where("("+strings.Join(conditions, " OR ")+")", args...).
+		Group("s.id, s.start_time").
+		Order("s.start_time ASC").
+		Scan(&schedules).Error
+
+	return schedules, err
 }
 
 func (r *movieRepo) UpdateStatus(id uuid.UUID, status bool) error {
@@ -101,3 +275,178 @@ func (r *movieRepo) UpdateStatus(id uuid.UUID, status bool) error {
 		Where("id = ?", id).
 		Update("status", status).Error
 }
+
+func applyMovieFilter(query *gorm.DB, filter MovieFilter) *gorm.DB {
+	if filter.Genre != "" {
+		query = query.Where(
+			"EXISTS (SELECT 1 FROM movie_genres mg JOIN genres g ON g.id = mg.genre_id WHERE mg.movie_id = movies.id AND g.slug = ?)",
+			filter.Genre,
+		)
+	}
+
+	if filter.Rating != "" {
+		query = query.Where("rating = ?", filter.Rating)
+	}
+
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	if filter.MinDuration > 0 {
+		query = query.Where("duration_minutes >= ?", filter.MinDuration)
+	}
+
+	if filter.MaxDuration > 0 {
+		query = query.Where("duration_minutes <= ?", filter.MaxDuration)
+	}
+
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+
+	if filter.State != "" {
+		query = query.Scopes(MovieStateScope(filter.State, filter.Today))
+	}
+
+	return query
+}
+
+// MovieStateScope memfilter movie berdasarkan state pada tanggal today, harus sama dengan Movies.StateOn.
+// release_date kosong (data lama) dianggap sudah tayang.
+func MovieStateScope(state entities.MovieState, today string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		switch state {
+		case entities.MovieComingSoon:
+			return db.Where("movies.release_date > ?", today)
+		case entities.MovieNowShowing:
+			return db.Where("(movies.release_date IS NULL OR movies.release_date <= ?) AND (movies.end_date IS NULL OR movies.end_date >= ?)", today, today)
+		case entities.MovieEnded:
+			return db.Where("movies.end_date < ?", today)
+		default:
+			return db
+		}
+	}
+}
+
+// Batas minimal word_similarity (pg_trgm) antara kata kunci dan judul, supaya judul yang salah ketik tetap ketemu.
+// Dipasang ke pg_trgm.word_similarity_threshold yang dipakai operator <%.
+const movieSearchSimilarity = "0.3"
+
+// ts_headline bekerja di teks mentah, jadi penanda highlight memakai karakter kontrol
+// yang baru diganti <mark> setelah teks di-escape (lihat highlightHTML)
+const (
+	highlightStart = "\x02"
+	highlightStop  = "\x03"
+
+	movieHeadlineOptions = "StartSel=" + highlightStart + ", StopSel=" + highlightStop
+)
+
+// SearchMovies mencari di kolom search_vector (judul, genre, deskripsi) dan judul yang mirip secara trigram.
+// Kolom search_vector, extension pg_trgm dan index trigram judul dibuat di migrasi.
+func (r *movieRepo) SearchMovies(search MovieSearch) ([]MovieSearchResult, int64, error) {
+	var (
+		movies []MovieSearchResult
+		total  int64
+	)
+
+	err := postgres.DB.Transaction(func(tx *gorm.DB) error {
+		// Threshold hanya berlaku di transaksi ini
+		if err := tx.Exec("SELECT set_config('pg_trgm.word_similarity_threshold', ?, true)", movieSearchSimilarity).Error; err != nil {
+			return err
+		}
+
+		query := applyMovieFilter(tx.Model(&entities.Movies{}), search.MovieFilter).
+			Joins("CROSS JOIN to_tsquery('simple', ?) AS q(query)", search.TSQuery).
+			Where("movies.search_vector @@ q.query OR ? <% movies.title", search.Text)
+
+		if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+			return err
+		}
+
+		query = query.Select(
+			`movies.*,
+			(ts_rank_cd(movies.search_vector, q.query) + word_similarity(?, movies.title))::float8 AS rank,
+			ts_headline('simple', movies.title, q.query, ?) AS title_highlight,
+			ts_headline('simple', movies.description, q.query, ?) AS snippet`,
+			search.Text,
+			movieHeadlineOptions+", HighlightAll=true",
+			movieHeadlineOptions+", MaxFragments=2, MaxWords=30, MinWords=10, FragmentDelimiter=\" ... \"",
+		)
+
+		// Rank dihitung per baris, jadi kondisi cursor diterapkan di luar subquery
+		results := tx.Table("(?) AS s", query)
+		if search.After != nil {
+			results = results.Where("(s.rank, s.id) < (?, ?)", search.After.Value, search.After.ID)
+		} else {
+			results = results.Offset(search.Offset)
+		}
+
+		return results.Order("s.rank DESC, s.id DESC").Limit(search.Limit).Scan(&movies).Error
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range movies {
+		movies[i].TitleHighlight = highlightHTML(movies[i].TitleHighlight)
+		movies[i].Snippet = highlightHTML(movies[i].Snippet)
+	}
+
+	// Hasil Scan dari subquery tidak bisa di-preload, genre diambil terpisah
+	if err := loadGenres(movies); err != nil {
+		return nil, 0, err
+	}
+
+	return movies, total, nil
+}
+
+// highlightHTML meng-escape hasil ts_headline lalu mengganti penanda highlight dengan <mark>,
+// sehingga HTML di judul/deskripsi tidak ikut dirender client
+func highlightHTML(text string) string {
+	return strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>").Replace(html.EscapeString(text))
+}
+
+func orderGenres(db *gorm.DB) *gorm.DB {
+	return db.Order("genres.name ASC")
+}
+
+func loadGenres(results []MovieSearchResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(results))
+	for i := range results {
+		ids[i] = results[i].ID
+	}
+
+	var rows []struct {
+		MovieID uuid.UUID
+		entities.Genre
+	}
+
+	err := postgres.DB.Table("movie_genres mg").
+		Select("mg.movie_id, genres.*").
+		Joins("JOIN genres ON genres.id = mg.genre_id").
+		Where("mg.movie_id IN ?", ids).
+		Order("genres.name ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	byMovie := make(map[uuid.UUID][]entities.Genre, len(results))
+	for _, row := range rows {
+		byMovie[row.MovieID] = append(byMovie[row.MovieID], row.Genre)
+	}
+
+	for i := range results {
+		results[i].Genres = byMovie[results[i].ID]
+	}
+
+	return nil
+}
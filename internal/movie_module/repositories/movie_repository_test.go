@@ -0,0 +1,25 @@
+package repositories
+
+import "testing"
+
+func TestHighlightHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"tanpa highlight", "Laskar Pelangi", "Laskar Pelangi"},
+		{"satu kata", highlightStart + "Laskar" + highlightStop + " Pelangi", "<mark>Laskar</mark> Pelangi"},
+		{"beberapa kata", highlightStart + "Laskar" + highlightStop + " " + highlightStart + "Pelangi" + highlightStop, "<mark>Laskar</mark> <mark>Pelangi</mark>"},
+		{"HTML di judul di-escape", "<script>alert(1)</script> " + highlightStart + "Film" + highlightStop, "&lt;script&gt;alert(1)&lt;/script&gt; <mark>Film</mark>"},
+		{"tag mark dari data di-escape", "<mark>Palsu</mark> & Asli", "&lt;mark&gt;Palsu&lt;/mark&gt; &amp; Asli"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := highlightHTML(tt.text); got != tt.want {
+				t.Errorf("highlightHTML(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
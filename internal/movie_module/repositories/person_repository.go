@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"errors"
+	"movie-ticket/infra/postgres"
+	"movie-ticket/internal/movie_module/entities"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PersonRepository interface {
+	List(search string, offset, limit int) ([]entities.Person, int64, error)
+	GetByID(id uuid.UUID) (*entities.Person, error)
+	GetByIDs(ids []uuid.UUID) ([]entities.Person, error)
+	Create(person *entities.Person) error
+	Update(person *entities.Person) error
+	Delete(id uuid.UUID) error
+	CountCredits(id uuid.UUID) (int64, error)
+	NowShowingCredits(id uuid.UUID, today string) ([]FilmographyCredit, error)
+}
+
+// FilmographyCredit adalah satu peran Person beserta data movie-nya
+type FilmographyCredit struct {
+	entities.Movies
+	Role         entities.CreditRole
+	Character    string
+	BillingOrder int
+}
+
+type personRepo struct{}
+
+func NewPersonRepo() PersonRepository {
+	return &personRepo{}
+}
+
+func (r *personRepo) List(search string, offset, limit int) ([]entities.Person, int64, error) {
+	query := postgres.DB.Model(&entities.Person{})
+
+	if search != "" {
+		query = query.Where(`name ILIKE ? ESCAPE '\'`, "%"+escapeLike(search)+"%")
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var people []entities.Person
+	err := query.Order("name ASC, id ASC").Offset(offset).Limit(limit).Find(&people).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return people, total, nil
+}
+
+func (r *personRepo) GetByID(id uuid.UUID) (*entities.Person, error) {
+	var person entities.Person
+
+	err := postgres.DB.Where("id = ?", id).First(&person).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &person, nil
+}
+
+func (r *personRepo) GetByIDs(ids []uuid.UUID) ([]entities.Person, error) {
+	var people []entities.Person
+
+	if err := postgres.DB.Where("id IN ?", ids).Find(&people).Error; err != nil {
+		return nil, err
+	}
+
+	return people, nil
+}
+
+func (r *personRepo) Create(person *entities.Person) error {
+	return postgres.DB.Create(person).Error
+}
+
+func (r *personRepo) Update(person *entities.Person) error {
+	return postgres.DB.Model(&entities.Person{}).
+		Where("id = ?", person.ID).
+		Updates(map[string]interface{}{
+			"name":       person.Name,
+			"biography":  person.Biography,
+			"birth_date": person.BirthDate,
+			"photo_url":  person.Photo_Url,
+			"updated_at": person.Updated_At,
+		}).Error
+}
+
+func (r *personRepo) Delete(id uuid.UUID) error {
+	return postgres.DB.Delete(&entities.Person{}, "id = ?", id).Error
+}
+
+func (r *personRepo) CountCredits(id uuid.UUID) (int64, error) {
+	var count int64
+	err := postgres.DB.Model(&entities.MovieCredit{}).Where("person_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+// NowShowingCredits mengambil peran di movie yang masih dalam masa tayang pada tanggal today.
+// Sama dengan state now_showing, status movie tidak ikut dihitung.
+func (r *personRepo) NowShowingCredits(id uuid.UUID, today string) ([]FilmographyCredit, error) {
+	var credits []FilmographyCredit
+
+	err := postgres.DB.Table("movie_credits mc").
+		Select("movies.*, mc.role, mc.character, mc.billing_order").
+		Joins("JOIN movies ON movies.id = mc.movie_id").
+		Where("mc.person_id = ?", id).
+		Scopes(MovieStateScope(entities.MovieNowShowing, today)).
+		Order("movies.title ASC, mc.role ASC").
+		Scan(&credits).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return credits, nil
+}
+
+// escapeLike meng-escape wildcard LIKE supaya nama dicocokkan apa adanya
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
@@ -0,0 +1,261 @@
+package services
+
+import (
+	"fmt"
+	customerror "movie-ticket/internal/movie_module/custom_error"
+	"movie-ticket/internal/movie_module/dto"
+	"movie-ticket/internal/movie_module/entities"
+	"movie-ticket/internal/movie_module/repositories"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type GenreService interface {
+	GetGenres() ([]*dto.GenreResponse, error)
+	CreateGenre(role string, req *dto.GenreRequest) (*dto.GenreResponse, error)
+	UpdateGenre(role, id string, req *dto.GenreRequest) (*dto.GenreResponse, error)
+	DeleteGenre(role, id string) error
+}
+
+type genreSvc struct {
+	repo      repositories.GenreRepository
+	validator *validator.Validate
+}
+
+func NewGenreService(r repositories.GenreRepository) GenreService {
+	return &genreSvc{
+		repo:      r,
+		validator: validator.New(),
+	}
+}
+
+func (s *genreSvc) GetGenres() ([]*dto.GenreResponse, error) {
+	genres, err := s.repo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	response := make([]*dto.GenreResponse, len(genres))
+	for i := range genres {
+		response[i] = &dto.GenreResponse{
+			Genre:      toGenreDTO(&genres[i].Genre),
+			MovieCount: genres[i].MovieCount,
+		}
+	}
+
+	return response, nil
+}
+
+func (s *genreSvc) CreateGenre(role string, req *dto.GenreRequest) (*dto.GenreResponse, error) {
+	if role != "admin" {
+		return nil, fmt.Errorf("%w", customerror.ErrUnauthorizedUser)
+	}
+
+	name, slug, err := s.validateGenre(req)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetBySlug(slug)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if existing != nil {
+		return nil, fmt.Errorf("%w: %s", customerror.ErrGenreExists, existing.Name)
+	}
+
+	genre := &entities.Genre{
+		ID:         uuid.New(),
+		Name:       name,
+		Slug:       slug,
+		Created_At: time.Now(),
+		Updated_At: time.Now(),
+	}
+
+	if err := s.repo.Create(genre); err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	return &dto.GenreResponse{Genre: toGenreDTO(genre)}, nil
+}
+
+// UpdateGenre mengganti nama genre. Slug ikut berubah, jadi filter lama dengan slug sebelumnya tidak berlaku lagi.
+func (s *genreSvc) UpdateGenre(role, id string, req *dto.GenreRequest) (*dto.GenreResponse, error) {
+	if role != "admin" {
+		return nil, fmt.Errorf("%w", customerror.ErrUnauthorizedUser)
+	}
+
+	genre, err := s.findGenre(id)
+	if err != nil {
+		return nil, err
+	}
+
+	name, slug, err := s.validateGenre(req)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetBySlug(slug)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if existing != nil && existing.ID != genre.ID {
+		return nil, fmt.Errorf("%w: %s", customerror.ErrGenreExists, existing.Name)
+	}
+
+	genre.Name = name
+	genre.Slug = slug
+	genre.Updated_At = time.Now()
+
+	if err := s.repo.Update(genre); err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	count, err := s.repo.CountMovies(genre.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	return &dto.GenreResponse{Genre: toGenreDTO(genre), MovieCount: count}, nil
+}
+
+// DeleteGenre hanya bisa untuk genre yang tidak dipakai movie manapun
+func (s *genreSvc) DeleteGenre(role, id string) error {
+	if role != "admin" {
+		return fmt.Errorf("%w", customerror.ErrUnauthorizedUser)
+	}
+
+	genre, err := s.findGenre(id)
+	if err != nil {
+		return err
+	}
+
+	count, err := s.repo.CountMovies(genre.ID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if count > 0 {
+		return fmt.Errorf("%w: %d movie(s)", customerror.ErrGenreInUse, count)
+	}
+
+	if err := s.repo.Delete(genre.ID); err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	return nil
+}
+
+func (s *genreSvc) findGenre(id string) (*entities.Genre, error) {
+	genreID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w", customerror.ErrInvalidGenreId)
+	}
+
+	genre, err := s.repo.GetByID(genreID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if genre == nil {
+		return nil, fmt.Errorf("%w", customerror.ErrGenreNotFound)
+	}
+
+	return genre, nil
+}
+
+func (s *genreSvc) validateGenre(req *dto.GenreRequest) (string, string, error) {
+	if req == nil {
+		return "", "", fmt.Errorf("%w", customerror.ErrInvalidInput)
+	}
+
+	if err := s.validator.Struct(req); err != nil {
+		return "", "", fmt.Errorf("%w: %v", customerror.ErrInvalidInput, err)
+	}
+
+	name := strings.Join(strings.Fields(req.Name), " ")
+	slug := entities.Slugify(name)
+	if slug == "" {
+		return "", "", fmt.Errorf("%w: name must contain at least one letter or digit", customerror.ErrInvalidInput)
+	}
+
+	return name, slug, nil
+}
+
+// Pemisah genre di teks lama, sama dengan migrasi di infra/postgres
+var legacyGenreSeparator = regexp.MustCompile(`\s*[,/|;]\s*`)
+
+// resolveGenres mengubah slug (atau teks genre lama) menjadi genre yang terdaftar, urut berdasarkan nama
+func (s *movieSvc) resolveGenres(slugs []string, legacy string) ([]entities.Genre, error) {
+	if len(slugs) == 0 && strings.TrimSpace(legacy) != "" {
+		slugs = legacyGenreSeparator.Split(legacy, -1)
+	}
+
+	seen := make(map[string]bool, len(slugs))
+	unique := make([]string, 0, len(slugs))
+	for _, raw := range slugs {
+		slug := entities.Slugify(raw)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		unique = append(unique, slug)
+	}
+
+	if len(unique) == 0 {
+		return nil, fmt.Errorf("%w: at least one genre is required", customerror.ErrInvalidInput)
+	}
+
+	genres, err := s.genreRepo.GetBySlugs(unique)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if len(genres) != len(unique) {
+		found := make(map[string]bool, len(genres))
+		for _, genre := range genres {
+			found[genre.Slug] = true
+		}
+
+		var missing []string
+		for _, slug := range unique {
+			if !found[slug] {
+				missing = append(missing, slug)
+			}
+		}
+		return nil, fmt.Errorf("%w: %s", customerror.ErrGenreNotFound, strings.Join(missing, ", "))
+	}
+
+	return genres, nil
+}
+
+// genreText adalah isi kolom movies.genre, harus sama dengan string_agg di repository dan migrasi
+func genreText(genres []entities.Genre) string {
+	names := make([]string, len(genres))
+	for i, genre := range genres {
+		names[i] = genre.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+func toGenreDTO(genre *entities.Genre) dto.Genre {
+	return dto.Genre{
+		ID:   genre.ID,
+		Name: genre.Name,
+		Slug: genre.Slug,
+	}
+}
+
+func toGenreDTOs(genres []entities.Genre) []dto.Genre {
+	response := make([]dto.Genre, len(genres))
+	for i := range genres {
+		response[i] = toGenreDTO(&genres[i])
+	}
+	return response
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"fmt"
+	customerror "movie-ticket/internal/movie_module/custom_error"
+	"movie-ticket/internal/movie_module/dto"
+	"movie-ticket/internal/movie_module/entities"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SetCredits mengganti seluruh sutradara, penulis, dan pemeran movie. BillingOrder yang kosong
+// diisi sesuai urutan di request per peran.
+func (s *movieSvc) SetCredits(role, id string, req *dto.SetCreditsRequest) (*dto.MovieResponse, error) {
+	if role != "admin" {
+		return nil, fmt.Errorf("%w", customerror.ErrUnauthorizedUser)
+	}
+
+	movieId, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w", customerror.ErrInvalidMovieId)
+	}
+
+	if req == nil {
+		return nil, fmt.Errorf("%w", customerror.ErrInvalidInput)
+	}
+
+	if err := s.validator.Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrInvalidCredits, err)
+	}
+
+	movie, err := s.repo.GetMovieById(movieId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if movie == nil {
+		return nil, fmt.Errorf("%w", customerror.ErrMovieNotFound)
+	}
+
+	credits := make([]entities.MovieCredit, 0, len(req.Credits))
+	seen := make(map[string]bool, len(req.Credits))
+	positions := make(map[entities.CreditRole]int)
+	personIDs := make([]uuid.UUID, 0, len(req.Credits))
+	now := time.Now()
+
+	for _, item := range req.Credits {
+		creditRole := entities.CreditRole(item.Role)
+
+		key := item.PersonID.String() + "/" + item.Role
+		if seen[key] {
+			return nil, fmt.Errorf("%w: person %s listed twice as %s", customerror.ErrInvalidCredits, item.PersonID, item.Role)
+		}
+		seen[key] = true
+
+		positions[creditRole]++
+		billing := item.BillingOrder
+		if billing == 0 {
+			billing = positions[creditRole]
+		}
+
+		character := ""
+		if creditRole == entities.CreditCast {
+			character = strings.TrimSpace(item.Character)
+		}
+
+		credits = append(credits, entities.MovieCredit{
+			ID:           uuid.New(),
+			MovieID:      movie.ID,
+			PersonID:     item.PersonID,
+			Role:         creditRole,
+			Character:    character,
+			BillingOrder: billing,
+			Created_At:   now,
+		})
+		personIDs = append(personIDs, item.PersonID)
+	}
+
+	if len(personIDs) > 0 {
+		people, err := s.personRepo.GetByIDs(personIDs)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+		}
+
+		found := make(map[uuid.UUID]bool, len(people))
+		for _, person := range people {
+			found[person.ID] = true
+		}
+
+		for _, personID := range personIDs {
+			if !found[personID] {
+				return nil, fmt.Errorf("%w: %s", customerror.ErrPersonNotFound, personID)
+			}
+		}
+	}
+
+	if err := s.repo.ReplaceCredits(movie.ID, credits); err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	return s.GetMovieById(id)
+}
+
+func toMovieCredits(credits []entities.MovieCredit) *dto.MovieCredits {
+	response := &dto.MovieCredits{
+		Directors: []dto.CreditResponse{},
+		Writers:   []dto.CreditResponse{},
+		Cast:      []dto.CreditResponse{},
+	}
+
+	for _, credit := range credits {
+		item := dto.CreditResponse{
+			PersonID:     credit.PersonID,
+			Name:         credit.Person.Name,
+			Photo_Url:    credit.Person.Photo_Url,
+			Character:    credit.Character,
+			BillingOrder: credit.BillingOrder,
+		}
+
+		switch credit.Role {
+		case entities.CreditDirector:
+			response.Directors = append(response.Directors, item)
+		case entities.CreditWriter:
+			response.Writers = append(response.Writers, item)
+		case entities.CreditCast:
+			response.Cast = append(response.Cast, item)
+		}
+	}
+
+	return response
+}
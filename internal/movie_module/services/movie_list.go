@@ -0,0 +1,210 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	customerror "movie-ticket/internal/movie_module/custom_error"
+	"movie-ticket/internal/movie_module/dto"
+	"movie-ticket/internal/movie_module/entities"
+	"movie-ticket/internal/movie_module/repositories"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultMovieLimit = 10
+	maxMovieLimit     = 100
+	defaultMovieSort  = "-created_at"
+
+	// movieSearchSort hanya dipakai di cursor hasil pencarian yang diurutkan berdasarkan relevansi
+	movieSearchSort = "relevance"
+)
+
+// movieCursor di-encode base64url(JSON). Sort ikut disimpan supaya cursor tidak dipakai dengan urutan lain.
+type movieCursor struct {
+	Sort  string    `json:"s"`
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+func (s *movieSvc) buildMovieFilter(query *dto.MovieListQuery) (*repositories.MovieFilter, error) {
+	filter := &repositories.MovieFilter{
+		Genre:       strings.TrimSpace(query.Genre),
+		Rating:      query.Rating,
+		Status:      query.Status,
+		MinDuration: query.MinDuration,
+		MaxDuration: query.MaxDuration,
+		State:       entities.MovieState(query.State),
+		Limit:       query.Limit,
+	}
+
+	if filter.State != "" {
+		filter.Today = today().Format(entities.DateLayout)
+	}
+
+	if filter.Limit < 1 {
+		filter.Limit = defaultMovieLimit
+	}
+	if filter.Limit > maxMovieLimit {
+		filter.Limit = maxMovieLimit
+	}
+
+	if filter.MinDuration > 0 && filter.MaxDuration > 0 && filter.MinDuration > filter.MaxDuration {
+		return nil, fmt.Errorf("%w: min_duration must not be greater than max_duration", customerror.ErrInvalidInput)
+	}
+
+	var err error
+	if filter.CreatedFrom, err = parseDateParam(query.CreatedFrom, false); err != nil {
+		return nil, fmt.Errorf("%w: created_from %v", customerror.ErrInvalidInput, err)
+	}
+	if filter.CreatedTo, err = parseDateParam(query.CreatedTo, true); err != nil {
+		return nil, fmt.Errorf("%w: created_to %v", customerror.ErrInvalidInput, err)
+	}
+
+	sort := query.Sort
+	if query.Cursor != "" {
+		cursor, err := decodeMovieCursor(query.Cursor, sort)
+		if err != nil {
+			return nil, err
+		}
+		if cursor.sort == movieSearchSort {
+			return nil, fmt.Errorf("%w: search cursor cannot be used for movie list", customerror.ErrInvalidCursor)
+		}
+		filter.After = cursor.after
+		sort = cursor.sort
+	}
+
+	if sort == "" {
+		sort = defaultMovieSort
+	}
+	filter.SortDesc = strings.HasPrefix(sort, "-")
+	filter.SortColumn = strings.TrimPrefix(sort, "-")
+
+	if !repositories.MovieSortColumns[filter.SortColumn] {
+		return nil, fmt.Errorf("%w: unknown sort %s", customerror.ErrInvalidInput, sort)
+	}
+
+	if filter.After == nil {
+		page := query.Page
+		if page < 1 {
+			page = 1
+		}
+		filter.Offset = (page - 1) * filter.Limit
+	}
+
+	return filter, nil
+}
+
+// parseDateParam menerima YYYY-MM-DD atau RFC3339. Untuk batas akhir, tanggal saja
+// dijadikan awal hari berikutnya karena filter created_to bersifat eksklusif.
+func parseDateParam(value string, endOfDay bool) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("must be YYYY-MM-DD or RFC3339")
+	}
+
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
+
+func sortParam(column string, desc bool) string {
+	if desc {
+		return "-" + column
+	}
+	return column
+}
+
+func encodeMovieCursor(movie *entities.Movies, column string, desc bool) string {
+	cursor := movieCursor{Sort: sortParam(column, desc), ID: movie.ID}
+
+	switch column {
+	case "created_at":
+		cursor.Value = movie.Created_At.UTC().Format(time.RFC3339Nano)
+	case "title":
+		cursor.Value = movie.Title
+	case "duration_minutes":
+		cursor.Value = strconv.Itoa(movie.Duration_Minutes)
+	case "rating":
+		cursor.Value = movie.Rating
+	}
+
+	return encodeCursor(cursor)
+}
+
+func encodeSearchCursor(id uuid.UUID, rank float64) string {
+	return encodeCursor(movieCursor{
+		Sort:  movieSearchSort,
+		Value: strconv.FormatFloat(rank, 'g', -1, 64),
+		ID:    id,
+	})
+}
+
+func encodeCursor(cursor movieCursor) string {
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+type decodedMovieCursor struct {
+	sort  string
+	after *repositories.MovieCursor
+}
+
+// decodeMovieCursor mengubah cursor menjadi posisi untuk repository, nilai sort dikembalikan ke tipe kolomnya
+func decodeMovieCursor(raw, sort string) (*decodedMovieCursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w", customerror.ErrInvalidCursor)
+	}
+
+	var cursor movieCursor
+	if err := json.Unmarshal(payload, &cursor); err != nil || cursor.ID == uuid.Nil {
+		return nil, fmt.Errorf("%w", customerror.ErrInvalidCursor)
+	}
+
+	if sort != "" && sort != cursor.Sort {
+		return nil, fmt.Errorf("%w: cursor was created for sort %s", customerror.ErrInvalidCursor, cursor.Sort)
+	}
+
+	after := &repositories.MovieCursor{ID: cursor.ID}
+	switch strings.TrimPrefix(cursor.Sort, "-") {
+	case "created_at":
+		t, err := time.Parse(time.RFC3339Nano, cursor.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w", customerror.ErrInvalidCursor)
+		}
+		after.Value = t
+	case "duration_minutes":
+		n, err := strconv.Atoi(cursor.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w", customerror.ErrInvalidCursor)
+		}
+		after.Value = n
+	case "title", "rating":
+		after.Value = cursor.Value
+	case movieSearchSort:
+		rank, err := strconv.ParseFloat(cursor.Value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w", customerror.ErrInvalidCursor)
+		}
+		after.Value = rank
+	default:
+		return nil, fmt.Errorf("%w", customerror.ErrInvalidCursor)
+	}
+
+	return &decodedMovieCursor{sort: cursor.Sort, after: after}, nil
+}
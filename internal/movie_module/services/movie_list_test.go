@@ -0,0 +1,80 @@
+package services
+
+import (
+	"errors"
+	customerror "movie-ticket/internal/movie_module/custom_error"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestMovieCursorRoundTrip(t *testing.T) {
+	id := uuid.MustParse("7d1f3f5e-2d7b-4a36-9a49-0c1c2f7b8e11")
+	createdAt := time.Date(2026, 3, 10, 8, 30, 15, 123456789, time.UTC)
+
+	tests := []struct {
+		name   string
+		cursor movieCursor
+		sort   string
+		want   any
+	}{
+		{"created_at", movieCursor{Sort: "-created_at", Value: createdAt.Format(time.RFC3339Nano), ID: id}, "-created_at", createdAt},
+		{"durasi", movieCursor{Sort: "duration_minutes", Value: "125", ID: id}, "duration_minutes", 125},
+		{"judul", movieCursor{Sort: "-title", Value: "Laskar Pelangi", ID: id}, "-title", "Laskar Pelangi"},
+		{"rating", movieCursor{Sort: "rating", Value: "PG-13", ID: id}, "rating", "PG-13"},
+		{"sort kosong memakai sort cursor", movieCursor{Sort: "title", Value: "Dilan", ID: id}, "", "Dilan"},
+		{"relevansi", movieCursor{Sort: movieSearchSort, Value: "0.4375", ID: id}, movieSearchSort, 0.4375},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded, err := decodeMovieCursor(encodeCursor(tt.cursor), tt.sort)
+			if err != nil {
+				t.Fatalf("decodeMovieCursor: %v", err)
+			}
+			if decoded.sort != tt.cursor.Sort {
+				t.Errorf("sort = %q, want %q", decoded.sort, tt.cursor.Sort)
+			}
+			if decoded.after.ID != id {
+				t.Errorf("id = %s, want %s", decoded.after.ID, id)
+			}
+			if want, ok := tt.want.(time.Time); ok {
+				if got, _ := decoded.after.Value.(time.Time); !got.Equal(want) {
+					t.Errorf("value = %v, want %v", decoded.after.Value, want)
+				}
+				return
+			}
+			if decoded.after.Value != tt.want {
+				t.Errorf("value = %#v, want %#v", decoded.after.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeMovieCursorInvalid(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name string
+		raw  string
+		sort string
+	}{
+		{"bukan base64", "%%%", ""},
+		{"bukan JSON", "bm90LWpzb24", ""},
+		{"tanpa id", encodeCursor(movieCursor{Sort: "title", Value: "A"}), ""},
+		{"sort berbeda", encodeCursor(movieCursor{Sort: "title", Value: "A", ID: id}), "-title"},
+		{"sort tidak dikenal", encodeCursor(movieCursor{Sort: "genre", Value: "A", ID: id}), ""},
+		{"durasi bukan angka", encodeCursor(movieCursor{Sort: "duration_minutes", Value: "dua", ID: id}), ""},
+		{"waktu tidak valid", encodeCursor(movieCursor{Sort: "created_at", Value: "kemarin", ID: id}), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeMovieCursor(tt.raw, tt.sort)
+			if !errors.Is(err, customerror.ErrInvalidCursor) {
+				t.Errorf("error = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"fmt"
+	customerror "movie-ticket/internal/movie_module/custom_error"
+	"movie-ticket/internal/movie_module/dto"
+	"movie-ticket/internal/movie_module/repositories"
+	"strings"
+	"unicode"
+)
+
+// Kata kunci dibatasi supaya tsquery tidak terlalu panjang
+const maxSearchTerms = 10
+
+// SearchMovies mencari movie berdasarkan judul, genre, dan deskripsi. Setiap kata dicocokkan
+// sebagai prefix ("aveng" menemukan "Avengers"), judul yang salah ketik ditemukan lewat trigram.
+func (s *movieSvc) SearchMovies(query *dto.MovieSearchQuery) (*dto.MovieSearchResponse, error) {
+	if query == nil {
+		return nil, fmt.Errorf("%w", customerror.ErrInvalidInput)
+	}
+
+	terms := searchTerms(query.Q)
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("%w: q must contain at least one letter or digit", customerror.ErrInvalidInput)
+	}
+
+	filter, err := s.buildMovieFilter(&dto.MovieListQuery{
+		Page:   query.Page,
+		Limit:  query.Limit,
+		Genre:  query.Genre,
+		Rating: query.Rating,
+		Status: query.Status,
+		State:  query.State,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if query.Cursor != "" {
+		cursor, err := decodeMovieCursor(query.Cursor, movieSearchSort)
+		if err != nil {
+			return nil, err
+		}
+		filter.After = cursor.after
+		filter.Offset = 0
+	}
+
+	prefixes := make([]string, len(terms))
+	for i, term := range terms {
+		prefixes[i] = term + ":*"
+	}
+
+	limit := filter.Limit
+	filter.Limit++
+
+	results, total, err := s.repo.SearchMovies(repositories.MovieSearch{
+		MovieFilter: *filter,
+		TSQuery:     strings.Join(prefixes, " & "),
+		Text:        strings.Join(terms, " "),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	hasNext := len(results) > limit
+	if hasNext {
+		results = results[:limit]
+	}
+
+	response := &dto.MovieSearchResponse{
+		Data: make([]*dto.MovieSearchItem, len(results)),
+		Meta: dto.MovieListMeta{
+			Limit: limit,
+			Total: total,
+			Sort:  movieSearchSort,
+		},
+	}
+
+	for i := range results {
+		response.Data[i] = &dto.MovieSearchItem{
+			MovieResponse: *s.toMovieResponse(&results[i].Movies),
+			Score:         results[i].Rank,
+			Highlight: dto.MovieHighlight{
+				Title:   results[i].TitleHighlight,
+				Snippet: results[i].Snippet,
+			},
+		}
+	}
+
+	if filter.After == nil {
+		response.Meta.Page = filter.Offset/limit + 1
+		response.Meta.TotalPages = int((total + int64(limit) - 1) / int64(limit))
+	}
+
+	if hasNext {
+		last := results[len(results)-1]
+		response.Meta.NextCursor = encodeSearchCursor(last.ID, last.Rank)
+	}
+
+	return response, nil
+}
+
+// searchTerms memecah kata kunci menjadi kata huruf/angka saja, sehingga aman dipakai di to_tsquery
+func searchTerms(q string) []string {
+	terms := strings.FieldsFunc(strings.ToLower(q), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	if len(terms) > maxSearchTerms {
+		terms = terms[:maxSearchTerms]
+	}
+
+	return terms
+}
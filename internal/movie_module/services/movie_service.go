@@ -3,6 +3,7 @@ package services
 import (
 	"errors"
 	"fmt"
+	"movie-ticket/config"
 	customerror "movie-ticket/internal/movie_module/custom_error"
 	"movie-ticket/internal/movie_module/dto"
 	"movie-ticket/internal/movie_module/entities"
@@ -17,47 +18,75 @@ import (
 
 type MoviesService interface {
 	CreateMovie(role string, req *dto.CreateMovieRequest) (*dto.MovieResponse, error)
-	GetMovies(page, limit int) ([]*dto.MovieResponse, error)
+	GetMovies(query *dto.MovieListQuery) (*dto.MovieListResponse, error)
+	SearchMovies(query *dto.MovieSearchQuery) (*dto.MovieSearchResponse, error)
 	GetMovieById(id string) (*dto.MovieResponse, error)
 	UpdateMovie(role, id string, req *dto.UpdateMovieRequest) (*dto.MovieResponse, error)
 	DeleteMovie(role, id string) error
 	PatchStatus(role, id string, status *dto.StatusMovieRequest) error
+	SetCredits(role, id string, req *dto.SetCreditsRequest) (*dto.MovieResponse, error)
 }
 
 type movieSvc struct {
-	repo      repositories.MovieRepository
-	validator *validator.Validate
+	repo       repositories.MovieRepository
+	genreRepo  repositories.GenreRepository
+	personRepo repositories.PersonRepository
+	validator  *validator.Validate
 }
 
-func NewMoviesService(r repositories.MovieRepository) MoviesService {
+func NewMoviesService(r repositories.MovieRepository, genreRepo repositories.GenreRepository, personRepo repositories.PersonRepository) MoviesService {
 	return &movieSvc{
-		repo:      r,
-		validator: validator.New(),
+		repo:       r,
+		genreRepo:  genreRepo,
+		personRepo: personRepo,
+		validator:  validator.New(),
 	}
 }
 
-func (s *movieSvc) GetMovies(page, limit int) ([]*dto.MovieResponse, error) {
-	if page < 1 {
-		page = 1
+func (s *movieSvc) GetMovies(query *dto.MovieListQuery) (*dto.MovieListResponse, error) {
+	if query == nil {
+		query = &dto.MovieListQuery{}
 	}
 
-	if limit < 1 || limit > 100 {
-		limit = 10
+	filter, err := s.buildMovieFilter(query)
+	if err != nil {
+		return nil, err
 	}
 
-	movies, err := s.repo.GetMovies()
+	// Ambil satu data lebih untuk mengetahui apakah masih ada halaman berikutnya
+	limit := filter.Limit
+	filter.Limit++
 
+	movies, total, err := s.repo.GetMovies(*filter)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
 	}
 
-	if len(movies) == 0 {
-		return nil, customerror.ErrMovieNotFound
+	hasNext := len(movies) > limit
+	if hasNext {
+		movies = movies[:limit]
+	}
+
+	response := &dto.MovieListResponse{
+		Data: make([]*dto.MovieResponse, len(movies)),
+		Meta: dto.MovieListMeta{
+			Limit: limit,
+			Total: total,
+			Sort:  sortParam(filter.SortColumn, filter.SortDesc),
+		},
+	}
+
+	for i := range movies {
+		response.Data[i] = s.toMovieResponse(&movies[i])
+	}
+
+	if filter.After == nil {
+		response.Meta.Page = filter.Offset/limit + 1
+		response.Meta.TotalPages = int((total + int64(limit) - 1) / int64(limit))
 	}
 
-	response := make([]*dto.MovieResponse, len(movies))
-	for i, movie := range movies {
-		response[i] = s.toMovieResponse(&movie)
+	if hasNext {
+		response.Meta.NextCursor = encodeMovieCursor(&movies[len(movies)-1], filter.SortColumn, filter.SortDesc)
 	}
 
 	return response, nil
@@ -103,18 +132,43 @@ func (s *movieSvc) CreateMovie(role string, req *dto.CreateMovieRequest) (*dto.M
 		return nil, fmt.Errorf("%w: %v", customerror.ErrMovieExists, existing)
 	}
 
+	genres, err := s.resolveGenres(req.Genres, req.Genre)
+	if err != nil {
+		return nil, err
+	}
+
+	releaseDate, err := parseMovieDate("release_date", req.Release_Date)
+	if err != nil {
+		return nil, err
+	}
+	if releaseDate == nil {
+		releaseDate = dateOnly(today())
+	}
+
+	endDate, err := parseMovieDate("end_date", req.End_Date)
+	if err != nil {
+		return nil, err
+	}
+
 	movie := &entities.Movies{
 		ID:               uuid.New(),
 		Title:            strings.TrimSpace(req.Title),
 		Description:      strings.TrimSpace(req.Description),
-		Genre:            strings.TrimSpace(req.Genre),
+		Genre:            genreText(genres),
+		Genres:           genres,
 		Duration_Minutes: req.Duration_Minutes,
 		Rating:           req.Rating,
 		Poster_Url:       req.Poster_Url,
+		Release_Date:     releaseDate,
+		End_Date:         endDate,
 		Created_At:       time.Now(),
 		Updated_At:       time.Now(),
 	}
 
+	if err := validateRunWindow(movie); err != nil {
+		return nil, err
+	}
+
 	if err := s.repo.CreateMovies(movie); err != nil {
 		return nil, fmt.Errorf("%w", customerror.ErrDatabaseError)
 	}
@@ -149,18 +203,65 @@ func (s *movieSvc) UpdateMovie(role, id string, req *dto.UpdateMovieRequest) (*d
 		return nil, fmt.Errorf("%w: %v", customerror.ErrMovieNotFound, existingMovie)
 	}
 
+	// Genres dikosongkan supaya repository tidak mengganti relasi genre jika tidak diubah
 	updateMovie := *existingMovie
+	updateMovie.Genres = nil
 	s.applyUpdates(&updateMovie, req)
 	updateMovie.Updated_At = time.Now()
 
+	if req.Genres != nil || req.Genre != nil {
+		legacy := ""
+		if req.Genre != nil {
+			legacy = *req.Genre
+		}
+
+		genres, err := s.resolveGenres(req.Genres, legacy)
+		if err != nil {
+			return nil, err
+		}
+		updateMovie.Genres = genres
+		updateMovie.Genre = genreText(genres)
+	}
+
+	if req.Release_Date != nil {
+		if updateMovie.Release_Date, err = parseMovieDate("release_date", *req.Release_Date); err != nil {
+			return nil, err
+		}
+		// Tanggal rilis tidak bisa dikosongkan
+		if updateMovie.Release_Date == nil {
+			return nil, fmt.Errorf("%w: release_date cannot be empty", customerror.ErrInvalidInput)
+		}
+	}
+
+	if req.End_Date != nil {
+		if updateMovie.End_Date, err = parseMovieDate("end_date", *req.End_Date); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := validateRunWindow(&updateMovie); err != nil {
+		return nil, err
+	}
+
 	if err := s.validateUpdatedMovie(req); err != nil {
 		return nil, err
 	}
 
+	// Masa tayang tidak boleh dipersempit jika ada jadwal (dan tiket terjual) di luar masa tayang baru
+	if req.Release_Date != nil || req.End_Date != nil {
+		if err := s.checkSchedulesInWindow(&updateMovie); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := s.repo.UpdateMovies(movieId, &updateMovie); err != nil {
 		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
 	}
 
+	if updateMovie.Genres == nil {
+		updateMovie.Genres = existingMovie.Genres
+	}
+
 	return s.toMovieResponse(&updateMovie), nil
 }
 
@@ -235,18 +336,93 @@ func (s *movieSvc) validateBusinessRules(req *dto.CreateMovieRequest) error {
 }
 
 func (s *movieSvc) toMovieResponse(movie *entities.Movies) *dto.MovieResponse {
-	return &dto.MovieResponse{
+	response := &dto.MovieResponse{
 		ID:               movie.ID,
 		Title:            movie.Title,
 		Description:      movie.Description,
 		Genre:            movie.Genre,
+		Genres:           toGenreDTOs(movie.Genres),
 		Duration_Minutes: movie.Duration_Minutes,
 		Rating:           movie.Rating,
 		Poster_Url:       movie.Poster_Url,
 		Status:           movie.Status,
+		State:            string(movie.StateOn(today())),
 		Created_At:       movie.Created_At,
 		Updated_At:       movie.Updated_At,
 	}
+
+	if movie.Release_Date != nil {
+		response.Release_Date = movie.Release_Date.Format(entities.DateLayout)
+	}
+	if movie.End_Date != nil {
+		response.End_Date = movie.End_Date.Format(entities.DateLayout)
+	}
+
+	// Credits hanya di-preload di detail movie
+	if movie.Credits != nil {
+		response.Credits = toMovieCredits(movie.Credits)
+	}
+
+	return response
+}
+
+// today adalah waktu sekarang di timezone bioskop, dipakai untuk menghitung state masa tayang
+func today() time.Time {
+	return time.Now().In(config.Location())
+}
+
+func dateOnly(t time.Time) *time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return &day
+}
+
+func parseMovieDate(field, value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse(entities.DateLayout, value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s must be YYYY-MM-DD", customerror.ErrInvalidInput, field)
+	}
+
+	return &date, nil
+}
+
+func validateRunWindow(movie *entities.Movies) error {
+	if movie.Release_Date != nil && movie.End_Date != nil && movie.End_Date.Before(*movie.Release_Date) {
+		return fmt.Errorf("%w: end_date must not be before release_date", customerror.ErrInvalidInput)
+	}
+	return nil
+}
+
+// Jumlah jadwal yang disebutkan di pesan error checkSchedulesInWindow
+const maxListedSchedules = 5
+
+func (s *movieSvc) checkSchedulesInWindow(movie *entities.Movies) error {
+	loc := config.Location()
+
+	schedules, err := s.repo.SchedulesOutsideWindow(movie.ID, movie.Release_Date, movie.End_Date, loc.String())
+	if err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if len(schedules) == 0 {
+		return nil
+	}
+
+	var sold int64
+	listed := make([]string, 0, maxListedSchedules)
+	for _, schedule := range schedules {
+		sold += schedule.SoldSeats
+		if len(listed) < maxListedSchedules {
+			listed = append(listed, fmt.Sprintf("%s at %s (%d sold)", schedule.ID, schedule.StartTime.In(loc).Format(time.RFC3339), schedule.SoldSeats))
+		}
+	}
+
+	return fmt.Errorf("%w: %d schedule(s) with %d sold seat(s), move or delete them first: %s",
+		customerror.ErrSchedulesOutside, len(schedules), sold, strings.Join(listed, ", "))
 }
 
 func (s *movieSvc) formatValidationError(err error) error {
@@ -289,9 +465,6 @@ func (s *movieSvc) applyUpdates(movie *entities.Movies, req *dto.UpdateMovieRequ
 	if req.Description != nil {
 		movie.Description = strings.TrimSpace(*req.Description)
 	}
-	if req.Genre != nil {
-		movie.Genre = strings.TrimSpace(*req.Genre)
-	}
 	if req.Duration_Minutes != nil {
 		movie.Duration_Minutes = *req.Duration_Minutes
 	}
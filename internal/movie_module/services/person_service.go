@@ -0,0 +1,275 @@
+package services
+
+import (
+	"fmt"
+	customerror "movie-ticket/internal/movie_module/custom_error"
+	"movie-ticket/internal/movie_module/dto"
+	"movie-ticket/internal/movie_module/entities"
+	"movie-ticket/internal/movie_module/repositories"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type PersonService interface {
+	GetPeople(query *dto.PeopleListQuery) (*dto.PeopleListResponse, error)
+	GetPersonById(id string) (*dto.PersonDetailResponse, error)
+	CreatePerson(role string, req *dto.CreatePersonRequest) (*dto.PersonResponse, error)
+	UpdatePerson(role, id string, req *dto.UpdatePersonRequest) (*dto.PersonResponse, error)
+	DeletePerson(role, id string) error
+}
+
+type personSvc struct {
+	repo      repositories.PersonRepository
+	validator *validator.Validate
+}
+
+func NewPersonService(r repositories.PersonRepository) PersonService {
+	return &personSvc{
+		repo:      r,
+		validator: validator.New(),
+	}
+}
+
+func (s *personSvc) GetPeople(query *dto.PeopleListQuery) (*dto.PeopleListResponse, error) {
+	if query == nil {
+		query = &dto.PeopleListQuery{}
+	}
+
+	page := query.Page
+	if page < 1 {
+		page = 1
+	}
+
+	limit := query.Limit
+	if limit < 1 {
+		limit = defaultMovieLimit
+	}
+	if limit > maxMovieLimit {
+		limit = maxMovieLimit
+	}
+
+	people, total, err := s.repo.List(strings.TrimSpace(query.Q), (page-1)*limit, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	response := &dto.PeopleListResponse{
+		Data:       make([]*dto.PersonResponse, len(people)),
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	}
+
+	for i := range people {
+		response.Data[i] = toPersonResponse(&people[i])
+	}
+
+	return response, nil
+}
+
+// GetPersonById mengembalikan profil beserta peran di movie yang sedang tayang
+func (s *personSvc) GetPersonById(id string) (*dto.PersonDetailResponse, error) {
+	person, err := s.findPerson(id)
+	if err != nil {
+		return nil, err
+	}
+
+	credits, err := s.repo.NowShowingCredits(person.ID, today().Format(entities.DateLayout))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	response := &dto.PersonDetailResponse{
+		PersonResponse: *toPersonResponse(person),
+		NowShowing:     make([]dto.FilmographyItem, len(credits)),
+	}
+
+	for i, credit := range credits {
+		response.NowShowing[i] = dto.FilmographyItem{
+			MovieID:      credit.ID,
+			Title:        credit.Title,
+			Poster_Url:   credit.Poster_Url,
+			Rating:       credit.Rating,
+			Role:         string(credit.Role),
+			Character:    credit.Character,
+			BillingOrder: credit.BillingOrder,
+		}
+	}
+
+	return response, nil
+}
+
+func (s *personSvc) CreatePerson(role string, req *dto.CreatePersonRequest) (*dto.PersonResponse, error) {
+	if role != "admin" {
+		return nil, fmt.Errorf("%w", customerror.ErrUnauthorizedUser)
+	}
+
+	if req == nil {
+		return nil, fmt.Errorf("%w", customerror.ErrInvalidInput)
+	}
+
+	if err := s.validator.Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrInvalidInput, err)
+	}
+
+	birthDate, err := parseBirthDate(req.BirthDate)
+	if err != nil {
+		return nil, err
+	}
+
+	person := &entities.Person{
+		ID:         uuid.New(),
+		Name:       strings.TrimSpace(req.Name),
+		Biography:  strings.TrimSpace(req.Biography),
+		BirthDate:  birthDate,
+		Photo_Url:  strings.TrimSpace(req.Photo_Url),
+		Created_At: time.Now(),
+		Updated_At: time.Now(),
+	}
+
+	if err := s.repo.Create(person); err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	return toPersonResponse(person), nil
+}
+
+func (s *personSvc) UpdatePerson(role, id string, req *dto.UpdatePersonRequest) (*dto.PersonResponse, error) {
+	if role != "admin" {
+		return nil, fmt.Errorf("%w", customerror.ErrUnauthorizedUser)
+	}
+
+	if req == nil {
+		return nil, fmt.Errorf("%w", customerror.ErrInvalidInput)
+	}
+
+	if err := s.validator.Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrInvalidInput, err)
+	}
+
+	person, err := s.findPerson(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: name cannot be empty", customerror.ErrInvalidInput)
+		}
+		person.Name = name
+	}
+
+	if req.Biography != nil {
+		person.Biography = strings.TrimSpace(*req.Biography)
+	}
+
+	if req.BirthDate != nil {
+		if person.BirthDate, err = parseBirthDate(*req.BirthDate); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Photo_Url != nil {
+		photo := strings.TrimSpace(*req.Photo_Url)
+		if photo != "" {
+			if _, err := url.ParseRequestURI(photo); err != nil {
+				return nil, fmt.Errorf("%w: photo_url must be a valid URL", customerror.ErrInvalidInput)
+			}
+		}
+		person.Photo_Url = photo
+	}
+
+	person.Updated_At = time.Now()
+
+	if err := s.repo.Update(person); err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	return toPersonResponse(person), nil
+}
+
+// DeletePerson hanya bisa untuk orang yang tidak punya credit di movie manapun
+func (s *personSvc) DeletePerson(role, id string) error {
+	if role != "admin" {
+		return fmt.Errorf("%w", customerror.ErrUnauthorizedUser)
+	}
+
+	person, err := s.findPerson(id)
+	if err != nil {
+		return err
+	}
+
+	count, err := s.repo.CountCredits(person.ID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if count > 0 {
+		return fmt.Errorf("%w: %d credit(s)", customerror.ErrPersonHasCredits, count)
+	}
+
+	if err := s.repo.Delete(person.ID); err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	return nil
+}
+
+func (s *personSvc) findPerson(id string) (*entities.Person, error) {
+	personID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w", customerror.ErrInvalidPersonId)
+	}
+
+	person, err := s.repo.GetByID(personID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if person == nil {
+		return nil, fmt.Errorf("%w", customerror.ErrPersonNotFound)
+	}
+
+	return person, nil
+}
+
+func parseBirthDate(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse(entities.DateLayout, value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: birth_date must be YYYY-MM-DD", customerror.ErrInvalidInput)
+	}
+
+	if date.After(time.Now()) {
+		return nil, fmt.Errorf("%w: birth_date cannot be in the future", customerror.ErrInvalidInput)
+	}
+
+	return &date, nil
+}
+
+func toPersonResponse(person *entities.Person) *dto.PersonResponse {
+	response := &dto.PersonResponse{
+		ID:         person.ID,
+		Name:       person.Name,
+		Biography:  person.Biography,
+		Photo_Url:  person.Photo_Url,
+		Created_At: person.Created_At,
+		Updated_At: person.Updated_At,
+	}
+
+	if person.BirthDate != nil {
+		response.BirthDate = person.BirthDate.Format(entities.DateLayout)
+	}
+
+	return response
+}
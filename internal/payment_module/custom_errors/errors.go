@@ -0,0 +1,19 @@
+package customerrors
+
+import "errors"
+
+var (
+	ErrProviderNotFound = errors.New("payment provider not found")
+	ErrInvalidAmount    = errors.New("payment amount must be greater than zero")
+	ErrChargeNotFound   = errors.New("charge not found")
+	ErrPaymentDeclined  = errors.New("payment declined")
+	ErrPaymentFailed    = errors.New("payment failed")
+	ErrPaymentNotFound  = errors.New("payment not found")
+	ErrPaymentActive    = errors.New("reservation already has an active payment")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrWebhookSecret    = errors.New("webhook secret is not configured")
+	ErrInvalidPayload   = errors.New("invalid webhook payload")
+	ErrDatabaseError    = errors.New("database error")
+	ErrRefundFailed     = errors.New("refund failed")
+	ErrRefundExceeded   = errors.New("refund amount exceeds refundable amount")
+)
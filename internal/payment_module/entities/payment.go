@@ -0,0 +1,52 @@
+package entities
+
+import (
+	reservation "movie-ticket/internal/reservation_module/entities"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PaymentStatus string
+
+const (
+	PaymentPending  PaymentStatus = "PENDING"  // charge dibuat, menunggu capture
+	PaymentCaptured PaymentStatus = "CAPTURED" // dana sudah ditarik
+	PaymentFailed   PaymentStatus = "FAILED"
+
+	PaymentRefunded          PaymentStatus = "REFUNDED"
+	PaymentPartiallyRefunded PaymentStatus = "PARTIALLY_REFUNDED"
+)
+
+type Payment struct {
+	ID             uuid.UUID     `gorm:"type:uuid;primaryKey" json:"id"`
+	ReservationID  uuid.UUID     `gorm:"type:uuid;not null;index" json:"reservation_id"`
+	Provider       string        `gorm:"type:varchar(30);not null;uniqueIndex:idx_payment_provider_ref" json:"provider"`
+	ProviderRef    string        `gorm:"type:varchar(100);not null;uniqueIndex:idx_payment_provider_ref" json:"provider_ref"`
+	Amount         int           `gorm:"not null" json:"amount"`
+	RefundedAmount int           `gorm:"not null;default:0" json:"refunded_amount"`
+	Currency       string        `gorm:"type:varchar(3);not null;default:'IDR'" json:"currency"`
+	Status         PaymentStatus `gorm:"type:varchar(30);not null;default:'PENDING'" json:"status"`
+	FailureReason  string        `gorm:"type:text" json:"failure_reason,omitempty"`
+	CapturedAt     *time.Time    `json:"captured_at,omitempty"`
+	RefundedAt     *time.Time    `json:"refunded_at,omitempty"`
+	CreatedAt      time.Time     `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time     `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Reservation reservation.Reservation `gorm:"foreignKey:ReservationID;references:ID" json:"-"`
+}
+
+func (Payment) TableName() string {
+	return "payments"
+}
+
+func (p *Payment) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	if p.Status == "" {
+		p.Status = PaymentPending
+	}
+	return nil
+}
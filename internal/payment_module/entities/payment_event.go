@@ -0,0 +1,31 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PaymentEvent mencatat webhook yang sudah diproses. Unique (provider, event_id)
+// membuat event yang dikirim ulang oleh provider tidak diproses dua kali.
+type PaymentEvent struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	Provider  string    `gorm:"type:varchar(30);not null;uniqueIndex:idx_payment_event_provider_event" json:"provider"`
+	EventID   string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_payment_event_provider_event" json:"event_id"`
+	Type      string    `gorm:"type:varchar(50);not null" json:"type"`
+	ChargeID  string    `gorm:"type:varchar(100);not null;index" json:"charge_id"`
+	Payload   string    `gorm:"type:text" json:"payload"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (PaymentEvent) TableName() string {
+	return "payment_events"
+}
+
+func (e *PaymentEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
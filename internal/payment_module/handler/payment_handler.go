@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	customerrors "movie-ticket/internal/payment_module/custom_errors"
+	service "movie-ticket/internal/payment_module/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Header berisi hex HMAC-SHA256 dari raw body request
+const SignatureHeader = "X-Webhook-Signature"
+
+type PaymentHandler struct {
+	webhookService service.WebhookService
+}
+
+func NewPaymentHandler(r *gin.RouterGroup, webhookService service.WebhookService) {
+	h := PaymentHandler{webhookService: webhookService}
+	r.POST("/payments/webhook/:provider", h.HandleWebhook)
+}
+
+type ErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+type SuccessResponse struct {
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// HandleWebhook godoc
+// @Summary Menerima webhook dari payment provider
+// @Description Endpoint publik untuk notifikasi payment provider. Signature HMAC-SHA256 dari raw body wajib dikirim di header X-Webhook-Signature. Event dengan ID yang sama hanya diproses sekali; charge.succeeded menjadikan reservasi PAID (atau di-refund otomatis jika reservasi sudah batal/expired) dan charge.failed menjadikannya CANCELED
+// @Tags Payments
+// @Accept json
+// @Produce json
+// @Param provider path string true "Nama provider, misal fake"
+// @Param X-Webhook-Signature header string true "Hex HMAC-SHA256 dari raw body"
+// @Success 200 {object} SuccessResponse "Event processed atau sudah pernah diproses"
+// @Failure 400 {object} ErrorResponse "Bad Request - Payload tidak valid"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Signature tidak valid"
+// @Failure 404 {object} ErrorResponse "Not Found - Provider atau payment tidak ditemukan"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Failure 503 {object} ErrorResponse "Service Unavailable - Webhook secret provider belum dikonfigurasi"
+// @Router /payments/webhook/{provider} [post]
+func (h *PaymentHandler) HandleWebhook(c *gin.Context) {
+	payload, err := c.GetRawData()
+	if err != nil || len(payload) == 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_payload",
+			Message: "Request body is required",
+		})
+		return
+	}
+
+	processed, err := h.webhookService.HandleWebhook(
+		c.Request.Context(),
+		c.Param("provider"),
+		payload,
+		c.GetHeader(SignatureHeader),
+	)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		errorType := "internal_error"
+
+		if errors.Is(err, customerrors.ErrProviderNotFound) {
+			statusCode = http.StatusNotFound
+			errorType = "provider_not_found"
+		} else if errors.Is(err, customerrors.ErrInvalidSignature) {
+			statusCode = http.StatusUnauthorized
+			errorType = "invalid_signature"
+		} else if errors.Is(err, customerrors.ErrWebhookSecret) {
+			statusCode = http.StatusServiceUnavailable
+			errorType = "webhook_not_configured"
+		} else if errors.Is(err, customerrors.ErrInvalidPayload) {
+			statusCode = http.StatusBadRequest
+			errorType = "invalid_payload"
+		} else if errors.Is(err, customerrors.ErrPaymentNotFound) {
+			statusCode = http.StatusNotFound
+			errorType = "payment_not_found"
+		}
+
+		c.JSON(statusCode, ErrorResponse{
+			Error:   errorType,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	message := "Event processed"
+	if !processed {
+		message = "Event already processed"
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: message,
+	})
+}
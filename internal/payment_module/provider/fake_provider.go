@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	customerrors "movie-ticket/internal/payment_module/custom_errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	FakeProviderName = "fake"
+
+	fakeChargePrefix = "fake_ch_"
+	fakeRefundPrefix = "fake_re_"
+
+	// Payment method yang bisa dipakai saat testing/local
+	FakeMethodSuccess  = "tok_success"
+	FakeMethodDeclined = "tok_declined"
+
+	// Secret bawaan versi lama, tidak boleh dipakai untuk verifikasi
+	insecureFakeWebhookSecret = "fake-webhook-secret"
+)
+
+// fakeProvider adalah gateway sandbox tanpa state dan tanpa koneksi keluar.
+// Semua charge berhasil kecuali memakai payment method tok_declined.
+type fakeProvider struct {
+	webhookSecret string
+}
+
+func NewFakeProvider(webhookSecret string) Provider {
+	return &fakeProvider{webhookSecret: webhookSecret}
+}
+
+func (p *fakeProvider) Name() string {
+	return FakeProviderName
+}
+
+func (p *fakeProvider) CreateCharge(ctx context.Context, req ChargeRequest) (*Charge, error) {
+	if req.Amount <= 0 {
+		return nil, fmt.Errorf("%w", customerrors.ErrInvalidAmount)
+	}
+
+	charge := &Charge{
+		ID:     fakeChargePrefix + uuid.NewString(),
+		Status: ChargeRequiresCapture,
+		Amount: req.Amount,
+	}
+
+	if req.PaymentMethod == FakeMethodDeclined {
+		charge.Status = ChargeFailed
+		charge.FailureReason = "card declined"
+	}
+
+	return charge, nil
+}
+
+func (p *fakeProvider) Capture(ctx context.Context, chargeID string) (*Charge, error) {
+	if !strings.HasPrefix(chargeID, fakeChargePrefix) {
+		return nil, fmt.Errorf("%w: %s", customerrors.ErrChargeNotFound, chargeID)
+	}
+
+	return &Charge{ID: chargeID, Status: ChargeSucceeded}, nil
+}
+
+func (p *fakeProvider) Refund(ctx context.Context, chargeID string, amount int) (*Refund, error) {
+	if !strings.HasPrefix(chargeID, fakeChargePrefix) {
+		return nil, fmt.Errorf("%w: %s", customerrors.ErrChargeNotFound, chargeID)
+	}
+
+	if amount <= 0 {
+		return nil, fmt.Errorf("%w", customerrors.ErrInvalidAmount)
+	}
+
+	return &Refund{
+		ID:       fakeRefundPrefix + uuid.NewString(),
+		ChargeID: chargeID,
+		Amount:   amount,
+	}, nil
+}
+
+type fakeWebhookPayload struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	ChargeID string `json:"charge_id"`
+	Amount   int    `json:"amount"`
+}
+
+// VerifyWebhook memeriksa signature hex HMAC-SHA256 dari body dengan webhook secret.
+// Tanpa secret (atau memakai secret bawaan) semua webhook ditolak.
+func (p *fakeProvider) VerifyWebhook(payload []byte, signature string) (*WebhookEvent, error) {
+	if p.webhookSecret == "" || p.webhookSecret == insecureFakeWebhookSecret {
+		return nil, fmt.Errorf("%w", customerrors.ErrWebhookSecret)
+	}
+
+	expected := p.sign(payload)
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(strings.TrimSpace(signature)))) {
+		return nil, fmt.Errorf("%w", customerrors.ErrInvalidSignature)
+	}
+
+	var body fakeWebhookPayload
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return nil, fmt.Errorf("%w: %v", customerrors.ErrInvalidPayload, err)
+	}
+
+	if body.ID == "" || body.Type == "" || body.ChargeID == "" {
+		return nil, fmt.Errorf("%w: id, type and charge_id are required", customerrors.ErrInvalidPayload)
+	}
+
+	return &WebhookEvent{
+		ID:       body.ID,
+		Type:     body.Type,
+		ChargeID: body.ChargeID,
+		Amount:   body.Amount,
+	}, nil
+}
+
+// sign menghasilkan signature hex HMAC-SHA256 untuk payload
+func (p *fakeProvider) sign(payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(p.webhookSecret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	customerrors "movie-ticket/internal/payment_module/custom_errors"
+
+	"github.com/google/uuid"
+)
+
+type ChargeStatus string
+
+const (
+	ChargeRequiresCapture ChargeStatus = "requires_capture"
+	ChargeSucceeded       ChargeStatus = "succeeded"
+	ChargeFailed          ChargeStatus = "failed"
+)
+
+// ChargeRequest adalah permintaan pembuatan charge/payment intent ke provider
+type ChargeRequest struct {
+	ReservationID uuid.UUID
+	Amount        int
+	Currency      string
+	PaymentMethod string
+	Description   string
+}
+
+type Charge struct {
+	ID            string
+	Status        ChargeStatus
+	Amount        int
+	FailureReason string
+}
+
+type Refund struct {
+	ID       string
+	ChargeID string
+	Amount   int
+}
+
+// Tipe event webhook yang dikenali
+const (
+	EventChargeSucceeded = "charge.succeeded"
+	EventChargeFailed    = "charge.failed"
+)
+
+// WebhookEvent adalah notifikasi dari provider yang sudah diverifikasi signature-nya
+type WebhookEvent struct {
+	ID       string
+	Type     string
+	ChargeID string
+	Amount   int
+}
+
+// Provider adalah kontrak payment gateway. Setiap gateway (fake, midtrans, stripe, dll)
+// cukup mengimplementasikan interface ini.
+type Provider interface {
+	Name() string
+	CreateCharge(ctx context.Context, req ChargeRequest) (*Charge, error)
+	Capture(ctx context.Context, chargeID string) (*Charge, error)
+	Refund(ctx context.Context, chargeID string, amount int) (*Refund, error)
+	VerifyWebhook(payload []byte, signature string) (*WebhookEvent, error)
+}
+
+// Registry menyimpan provider yang tersedia berdasarkan nama
+type Registry struct {
+	providers   map[string]Provider
+	defaultName string
+}
+
+func NewRegistry(defaultName string, providers ...Provider) *Registry {
+	r := &Registry{
+		providers:   make(map[string]Provider, len(providers)),
+		defaultName: defaultName,
+	}
+	for _, p := range providers {
+		r.providers[p.Name()] = p
+	}
+	return r
+}
+
+func (r *Registry) Get(name string) (Provider, error) {
+	p, ok := r.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", customerrors.ErrProviderNotFound, name)
+	}
+	return p, nil
+}
+
+// Default mengembalikan provider yang dipakai untuk charge baru (env PAYMENT_PROVIDER)
+func (r *Registry) Default() (Provider, error) {
+	return r.Get(r.defaultName)
+}
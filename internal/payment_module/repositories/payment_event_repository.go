@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"movie-ticket/internal/payment_module/entities"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// Kode error Postgres untuk pelanggaran unique constraint
+const uniqueViolationCode = "23505"
+
+type PaymentEventRepository interface {
+	// Create mengembalikan false jika event dengan provider + event_id yang sama sudah tercatat
+	Create(ctx context.Context, event *entities.PaymentEvent) (bool, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type paymentEventRepository struct {
+	db *gorm.DB
+}
+
+func NewPaymentEventRepository(db *gorm.DB) PaymentEventRepository {
+	return &paymentEventRepository{db: db}
+}
+
+func (r *paymentEventRepository) Create(ctx context.Context, event *entities.PaymentEvent) (bool, error) {
+	if err := r.db.WithContext(ctx).Create(event).Error; err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *paymentEventRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&entities.PaymentEvent{}, "id = ?", id).Error
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	customerrors "movie-ticket/internal/payment_module/custom_errors"
+	"movie-ticket/internal/payment_module/entities"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PaymentRepository interface {
+	Create(ctx context.Context, payment *entities.Payment) error
+	Update(ctx context.Context, payment *entities.Payment) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entities.Payment, error)
+	FindByProviderRef(ctx context.Context, provider string, ref string) (*entities.Payment, error)
+	LockCapturedByReservation(ctx context.Context, reservationID uuid.UUID, fn func(payment *entities.Payment) error) error
+}
+
+type paymentRepository struct {
+	db *gorm.DB
+}
+
+func NewPaymentRepository(db *gorm.DB) PaymentRepository {
+	return &paymentRepository{db: db}
+}
+
+// Create mengembalikan ErrPaymentActive jika reservasi sudah punya payment yang tidak gagal
+// (unique index idx_payments_reservation_active), sehingga satu reservasi hanya di-charge sekali
+func (r *paymentRepository) Create(ctx context.Context, payment *entities.Payment) error {
+	if err := r.db.WithContext(ctx).Create(payment).Error; err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode && pgErr.ConstraintName == "idx_payments_reservation_active" {
+			return fmt.Errorf("%w: %s", customerrors.ErrPaymentActive, payment.ReservationID)
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *paymentRepository) Update(ctx context.Context, payment *entities.Payment) error {
+	return r.db.WithContext(ctx).Save(payment).Error
+}
+
+// FindByID mengembalikan nil jika payment tidak ditemukan
+func (r *paymentRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Payment, error) {
+	return r.first(r.db.WithContext(ctx).Where("id = ?", id))
+}
+
+// FindByProviderRef mengembalikan nil jika payment tidak ditemukan
+func (r *paymentRepository) FindByProviderRef(ctx context.Context, provider string, ref string) (*entities.Payment, error) {
+	return r.first(r.db.WithContext(ctx).Where("provider = ? AND provider_ref = ?", provider, ref))
+}
+
+// LockCapturedByReservation mengunci payment captured terakhir (SELECT ... FOR UPDATE) selama fn
+// berjalan, payment nil jika tidak ada. Perubahan pada payment disimpan jika fn tidak mengembalikan error.
+// Request yang menunggu lock tidak lagi mendapat payment yang sudah di-refund oleh request sebelumnya.
+func (r *paymentRepository) LockCapturedByReservation(ctx context.Context, reservationID uuid.UUID, fn func(payment *entities.Payment) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		payment, err := r.first(tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("reservation_id = ? AND status = ?", reservationID, entities.PaymentCaptured).
+			Order("created_at DESC"))
+		if err != nil {
+			return err
+		}
+
+		if err := fn(payment); err != nil {
+			return err
+		}
+
+		if payment == nil {
+			return nil
+		}
+
+		return tx.Save(payment).Error
+	})
+}
+
+func (r *paymentRepository) first(query *gorm.DB) (*entities.Payment, error) {
+	var payment entities.Payment
+	if err := query.First(&payment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &payment, nil
+}
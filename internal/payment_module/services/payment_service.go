@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	customerrors "movie-ticket/internal/payment_module/custom_errors"
+	"movie-ticket/internal/payment_module/entities"
+	"movie-ticket/internal/payment_module/provider"
+	repository "movie-ticket/internal/payment_module/repositories"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const defaultCurrency = "IDR"
+
+type PaymentService interface {
+	// ChargeReservation membuat charge lalu langsung capture untuk sebuah reservasi
+	ChargeReservation(ctx context.Context, reservationID uuid.UUID, amount int, paymentMethod string) (*entities.Payment, error)
+	// RefundReservation mengembalikan sebagian/seluruh dana dari payment yang sudah captured
+	RefundReservation(ctx context.Context, reservationID uuid.UUID, amount int) (*entities.Payment, error)
+}
+
+type paymentService struct {
+	paymentRepo repository.PaymentRepository
+	providers   *provider.Registry
+}
+
+func NewPaymentService(paymentRepo repository.PaymentRepository, providers *provider.Registry) PaymentService {
+	return &paymentService{
+		paymentRepo: paymentRepo,
+		providers:   providers,
+	}
+}
+
+func (s *paymentService) ChargeReservation(ctx context.Context, reservationID uuid.UUID, amount int, paymentMethod string) (*entities.Payment, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("%w", customerrors.ErrInvalidAmount)
+	}
+
+	gateway, err := s.providers.Default()
+	if err != nil {
+		return nil, err
+	}
+
+	charge, err := gateway.CreateCharge(ctx, provider.ChargeRequest{
+		ReservationID: reservationID,
+		Amount:        amount,
+		Currency:      defaultCurrency,
+		PaymentMethod: paymentMethod,
+		Description:   fmt.Sprintf("Reservation %s", reservationID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerrors.ErrPaymentFailed, err)
+	}
+
+	payment := &entities.Payment{
+		ReservationID: reservationID,
+		Provider:      gateway.Name(),
+		ProviderRef:   charge.ID,
+		Amount:        amount,
+		Currency:      defaultCurrency,
+		Status:        entities.PaymentPending,
+	}
+
+	if charge.Status == provider.ChargeFailed {
+		payment.Status = entities.PaymentFailed
+		payment.FailureReason = charge.FailureReason
+	}
+
+	// Insert payment menjadi klaim atas reservasi. Charge yang kalah klaim tidak di-capture,
+	// sehingga dana tidak ditarik dua kali
+	if err := s.paymentRepo.Create(ctx, payment); err != nil {
+		if errors.Is(err, customerrors.ErrPaymentActive) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", customerrors.ErrDatabaseError, err)
+	}
+
+	if payment.Status == entities.PaymentFailed {
+		return payment, fmt.Errorf("%w: %s", customerrors.ErrPaymentDeclined, charge.FailureReason)
+	}
+
+	captured, err := gateway.Capture(ctx, charge.ID)
+	if err != nil || captured.Status != provider.ChargeSucceeded {
+		payment.Status = entities.PaymentFailed
+		if err != nil {
+			payment.FailureReason = err.Error()
+		} else {
+			payment.FailureReason = captured.FailureReason
+		}
+
+		if updateErr := s.paymentRepo.Update(ctx, payment); updateErr != nil {
+			fmt.Printf("Warning: failed to mark payment %s as failed: %v\n", payment.ID, updateErr)
+		}
+		return payment, fmt.Errorf("%w: capture failed: %s", customerrors.ErrPaymentFailed, payment.FailureReason)
+	}
+
+	now := time.Now()
+	payment.Status = entities.PaymentCaptured
+	payment.CapturedAt = &now
+	if err := s.paymentRepo.Update(ctx, payment); err != nil {
+		// Dana sudah ditarik tapi tidak tercatat, kembalikan supaya user tidak tertagih
+		if _, refundErr := gateway.Refund(ctx, charge.ID, amount); refundErr != nil {
+			fmt.Printf("Warning: payment %s captured but not saved and refund failed: %v\n", payment.ID, refundErr)
+		}
+		return nil, fmt.Errorf("%w: %v", customerrors.ErrDatabaseError, err)
+	}
+
+	return payment, nil
+}
+
+// RefundReservation mengunci payment selama refund ke provider sehingga refund yang bersamaan
+// untuk reservasi yang sama hanya berjalan sekali
+func (s *paymentService) RefundReservation(ctx context.Context, reservationID uuid.UUID, amount int) (*entities.Payment, error) {
+	if amount <= 0 {
+		return nil, fmt.Errorf("%w", customerrors.ErrInvalidAmount)
+	}
+
+	var refunded *entities.Payment
+	err := s.paymentRepo.LockCapturedByReservation(ctx, reservationID, func(payment *entities.Payment) error {
+		if payment == nil {
+			return fmt.Errorf("%w: no captured payment for reservation %s", customerrors.ErrPaymentNotFound, reservationID)
+		}
+
+		if amount > payment.Amount-payment.RefundedAmount {
+			return fmt.Errorf("%w: requested %d, refundable %d", customerrors.ErrRefundExceeded, amount, payment.Amount-payment.RefundedAmount)
+		}
+
+		gateway, err := s.providers.Get(payment.Provider)
+		if err != nil {
+			return err
+		}
+
+		if _, err := gateway.Refund(ctx, payment.ProviderRef, amount); err != nil {
+			return fmt.Errorf("%w: %v", customerrors.ErrRefundFailed, err)
+		}
+
+		now := time.Now()
+		payment.RefundedAmount += amount
+		payment.RefundedAt = &now
+		payment.Status = entities.PaymentPartiallyRefunded
+		if payment.RefundedAmount >= payment.Amount {
+			payment.Status = entities.PaymentRefunded
+		}
+
+		refunded = payment
+		return nil
+	})
+
+	if err != nil {
+		if refunded == nil {
+			return nil, err
+		}
+		// Dana sudah dikembalikan provider, catatan lokal perlu dibetulkan manual
+		fmt.Printf("Warning: refund for payment %s succeeded but was not saved: %v\n", refunded.ID, err)
+		return nil, fmt.Errorf("%w: %v", customerrors.ErrDatabaseError, err)
+	}
+
+	return refunded, nil
+}
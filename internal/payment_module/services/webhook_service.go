@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	customerrors "movie-ticket/internal/payment_module/custom_errors"
+	"movie-ticket/internal/payment_module/entities"
+	"movie-ticket/internal/payment_module/provider"
+	repository "movie-ticket/internal/payment_module/repositories"
+	reservationerrors "movie-ticket/internal/reservation_module/custom_errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReservationUpdater adalah bagian dari reservation service yang dipakai webhook.
+// Didefinisikan di sini supaya payment module tidak import reservation service.
+type ReservationUpdater interface {
+	ApplyPaymentResult(ctx context.Context, reservationID uuid.UUID, paid bool) error
+}
+
+type WebhookService interface {
+	// HandleWebhook mengembalikan false jika event sudah pernah diproses
+	HandleWebhook(ctx context.Context, providerName string, payload []byte, signature string) (bool, error)
+}
+
+type webhookService struct {
+	paymentRepo  repository.PaymentRepository
+	eventRepo    repository.PaymentEventRepository
+	providers    *provider.Registry
+	reservations ReservationUpdater
+}
+
+func NewWebhookService(paymentRepo repository.PaymentRepository, eventRepo repository.PaymentEventRepository, providers *provider.Registry, reservations ReservationUpdater) WebhookService {
+	return &webhookService{
+		paymentRepo:  paymentRepo,
+		eventRepo:    eventRepo,
+		providers:    providers,
+		reservations: reservations,
+	}
+}
+
+func (s *webhookService) HandleWebhook(ctx context.Context, providerName string, payload []byte, signature string) (bool, error) {
+	gateway, err := s.providers.Get(providerName)
+	if err != nil {
+		return false, err
+	}
+
+	event, err := gateway.VerifyWebhook(payload, signature)
+	if err != nil {
+		return false, err
+	}
+
+	record := &entities.PaymentEvent{
+		Provider: gateway.Name(),
+		EventID:  event.ID,
+		Type:     event.Type,
+		ChargeID: event.ChargeID,
+		Payload:  string(payload),
+	}
+
+	created, err := s.eventRepo.Create(ctx, record)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", customerrors.ErrDatabaseError, err)
+	}
+	if !created {
+		return false, nil
+	}
+
+	if err := s.apply(ctx, gateway.Name(), event); err != nil {
+		// Hapus catatan event supaya retry dari provider diproses ulang
+		if delErr := s.eventRepo.Delete(ctx, record.ID); delErr != nil {
+			fmt.Printf("Warning: failed to delete payment event %s: %v\n", record.ID, delErr)
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *webhookService) apply(ctx context.Context, providerName string, event *provider.WebhookEvent) error {
+	var (
+		status entities.PaymentStatus
+		paid   bool
+	)
+
+	switch event.Type {
+	case provider.EventChargeSucceeded:
+		status, paid = entities.PaymentCaptured, true
+	case provider.EventChargeFailed:
+		status, paid = entities.PaymentFailed, false
+	default:
+		// Event lain cukup dicatat
+		return nil
+	}
+
+	payment, err := s.paymentRepo.FindByProviderRef(ctx, providerName, event.ChargeID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", customerrors.ErrDatabaseError, err)
+	}
+	if payment == nil {
+		return fmt.Errorf("%w: charge %s", customerrors.ErrPaymentNotFound, event.ChargeID)
+	}
+
+	// Payment yang sudah captured/refund tidak boleh berubah karena event yang terlambat
+	settled := payment.Status == entities.PaymentRefunded || payment.Status == entities.PaymentPartiallyRefunded
+	if settled || (payment.Status == entities.PaymentCaptured && !paid) {
+		fmt.Printf("Warning: ignoring %s for captured payment %s\n", event.Type, payment.ID)
+		return nil
+	}
+
+	// Kegagalan yang sudah tercatat tidak membatalkan reservasi, user bisa mencoba bayar lagi
+	if payment.Status == entities.PaymentFailed && !paid {
+		return nil
+	}
+
+	// Nominal yang berbeda perlu dicek manual, reservasi tidak ditandai lunas
+	if paid && event.Amount != payment.Amount {
+		fmt.Printf("Warning: ignoring %s for payment %s: amount %d does not match %d\n", event.Type, payment.ID, event.Amount, payment.Amount)
+		return nil
+	}
+
+	if payment.Status != status {
+		payment.Status = status
+		if paid {
+			now := time.Now()
+			payment.CapturedAt = &now
+		} else {
+			payment.FailureReason = "failed via webhook " + event.ID
+		}
+
+		if err := s.paymentRepo.Update(ctx, payment); err != nil {
+			return fmt.Errorf("%w: %v", customerrors.ErrDatabaseError, err)
+		}
+	}
+
+	err = s.reservations.ApplyPaymentResult(ctx, payment.ReservationID, paid)
+	if errors.Is(err, reservationerrors.ErrInvalidStatusTransition) {
+		// Dana masuk untuk reservasi yang sudah batal/expired, kembalikan ke user
+		if paid {
+			return s.refundUnpayable(ctx, payment, event.ID)
+		}
+
+		// Status reservasi sudah final, retry tidak akan mengubah apa pun
+		fmt.Printf("Warning: webhook %s not applied to reservation %s: %v\n", event.ID, payment.ReservationID, err)
+		return nil
+	}
+
+	return err
+}
+
+// refundUnpayable mengembalikan seluruh sisa dana payment. Jika refund gagal error dikembalikan
+// supaya webhook di-retry; jika refund berhasil tapi gagal disimpan cukup di-log untuk rekonsiliasi.
+func (s *webhookService) refundUnpayable(ctx context.Context, payment *entities.Payment, eventID string) error {
+	amount := payment.Amount - payment.RefundedAmount
+	if amount <= 0 {
+		return nil
+	}
+
+	gateway, err := s.providers.Get(payment.Provider)
+	if err != nil {
+		return err
+	}
+
+	if _, err := gateway.Refund(ctx, payment.ProviderRef, amount); err != nil {
+		return fmt.Errorf("%w: %v", customerrors.ErrRefundFailed, err)
+	}
+
+	now := time.Now()
+	payment.RefundedAmount += amount
+	payment.RefundedAt = &now
+	payment.Status = entities.PaymentRefunded
+	payment.FailureReason = "auto refunded, reservation no longer payable (event " + eventID + ")"
+
+	if err := s.paymentRepo.Update(ctx, payment); err != nil {
+		fmt.Printf("Warning: payment %s auto refunded but not saved, reconcile manually: %v\n", payment.ID, err)
+		return nil
+	}
+
+	fmt.Printf("Warning: payment %s for reservation %s auto refunded after event %s\n", payment.ID, payment.ReservationID, eventID)
+	return nil
+}
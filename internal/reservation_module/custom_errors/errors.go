@@ -3,19 +3,28 @@ package customerrors
 import "errors"
 
 var (
-	ErrUnauthorizedUser       = errors.New("unauthorized user")
-	ErrOnlyUserCanReserve     = errors.New("only user role can create reservation")
-	ErrInvalidInput           = errors.New("invalid input")
-	ErrInvalidID              = errors.New("invalid id")
-	ErrScheduleNotFound       = errors.New("schedule not found")
-	ErrScheduleInactive       = errors.New("schedule is inactive")
-	ErrScheduleAlreadyStarted = errors.New("cannot book for a schedule that already started")
-	ErrSeatsRequired          = errors.New("at least one seat is required")
-	ErrSeatAlreadyBooked      = errors.New("one or more seats already booked")
-	ErrSeatOnHold             = errors.New("one or more seats currently on hold")
-	ErrDatabaseError          = errors.New("database error")
-	ErrReservationNotFound    = errors.New("reservation not found")
-	ErrForbidden              = errors.New("forbidden")
-	ErrAlreadyPaid            = errors.New("reservation already paid")
-	ErrAlreadyCanceled        = errors.New("reservation already canceled")
+	ErrUnauthorizedUser        = errors.New("unauthorized user")
+	ErrOnlyUserCanReserve      = errors.New("only user role can create reservation")
+	ErrInvalidInput            = errors.New("invalid input")
+	ErrInvalidID               = errors.New("invalid id")
+	ErrScheduleNotFound        = errors.New("schedule not found")
+	ErrScheduleInactive        = errors.New("schedule is inactive")
+	ErrScheduleAlreadyStarted  = errors.New("cannot book for a schedule that already started")
+	ErrSeatsRequired           = errors.New("at least one seat is required")
+	ErrSeatAlreadyBooked       = errors.New("one or more seats already booked")
+	ErrSeatOnHold              = errors.New("one or more seats currently on hold")
+	ErrDatabaseError           = errors.New("database error")
+	ErrReservationNotFound     = errors.New("reservation not found")
+	ErrForbidden               = errors.New("forbidden")
+	ErrAlreadyPaid             = errors.New("reservation already paid")
+	ErrAlreadyCanceled         = errors.New("reservation already canceled")
+	ErrInvalidPrice            = errors.New("invalid price")
+	ErrSeatNotFound            = errors.New("one or more seats do not exist in this studio")
+	ErrSeatBlocked             = errors.New("one or more seats are not available for sale")
+	ErrDuplicateSeat           = errors.New("duplicate seat in request")
+	ErrPriceMismatch           = errors.New("total price does not match server calculated price")
+	ErrInvalidStatusTransition = errors.New("invalid reservation status transition")
+	ErrRefundNotAllowed        = errors.New("reservation is not eligible for a refund")
+	ErrInvalidRefundAmount     = errors.New("refund amount must be between 1 and the total price")
+	ErrEmailNotVerified        = errors.New("verify your email before booking")
 )
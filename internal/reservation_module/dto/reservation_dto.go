@@ -9,7 +9,39 @@ import (
 type CreateReservationRequest struct {
 	ScheduleID string   `json:"schedule_id" validate:"required"`
 	Seats      []string `json:"seats" validate:"required"`
-	TotalPrice int      `json:"total_price" validate:"required"`
+	// Opsional. Total dihitung di server; jika diisi harus sama dengan hasil perhitungan
+	TotalPrice int `json:"total_price,omitempty" validate:"omitempty,min=0"`
+}
+
+type ConfirmReservationRequest struct {
+	// Opsional. Token/metode pembayaran dari payment gateway
+	PaymentMethod string `json:"payment_method,omitempty"`
+}
+
+type AdminRefundRequest struct {
+	// Opsional. Default refund penuh
+	Amount int `json:"amount,omitempty" binding:"omitempty,min=1"`
+}
+
+type RefundResponse struct {
+	ReservationID uuid.UUID `json:"reservation_id"`
+	Status        string    `json:"status"`
+	TotalPrice    int       `json:"total_price"`
+	RefundAmount  int       `json:"refund_amount"`
+	RefundedAt    time.Time `json:"refunded_at"`
+}
+
+type PriceLineItem struct {
+	SeatCode string `json:"seat_code"`
+	Category string `json:"category"`
+	Price    int    `json:"price"`
+}
+
+type PriceQuote struct {
+	BasePrice int             `json:"base_price"`
+	SeatCount int             `json:"seat_count"`
+	Items     []PriceLineItem `json:"items"`
+	Total     int             `json:"total"`
 }
 
 type ReservationResponse struct {
@@ -17,7 +49,7 @@ type ReservationResponse struct {
 	UserID     uuid.UUID `json:"user_id"`
 	ScheduleID uuid.UUID `json:"schedule_id"`
 	TotalPrice int       `json:"total_price"`
-	Status     string    `json:"status"` // PENDING | PAID | CANCELED
+	Status     string    `json:"status"` // PENDING | PAID | CANCELED | EXPIRED | REFUNDED | PARTIALLY_REFUNDED
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
 }
@@ -38,9 +70,9 @@ type ReservationHistory struct {
 	ExpiresAt  time.Time `json:"expires_at"`
 
 	// Schedule
-	StartTime string `json:"start_time"`
-	EndTime   string `json:"end_time"`
-	Price     int    `json:"price"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Price     int       `json:"price"`
 
 	// Movie
 	MovieTitle  string `json:"movie_title"`
@@ -54,3 +86,39 @@ type ReservationHistory struct {
 	// Seats (akan diisi manual setelah query kedua)
 	Seats []string `json:"seats"`
 }
+
+// Status kursi pada denah ketersediaan
+const (
+	SeatStateAvailable = "available"
+	SeatStateHeld      = "held"
+	SeatStateHeldByMe  = "held_by_me"
+	SeatStateSold      = "sold"
+	SeatStateBlocked   = "blocked"
+)
+
+type SeatAvailability struct {
+	Code        string `json:"code"`
+	RowLabel    string `json:"row_label"`
+	Number      int    `json:"number"`
+	RowIndex    int    `json:"row_index"`
+	ColumnIndex int    `json:"column_index"`
+	Type        string `json:"type"`
+	Price       int    `json:"price"`
+	State       string `json:"state"` // available | held | held_by_me | sold | blocked
+}
+
+type SeatAvailabilityResponse struct {
+	ScheduleID uuid.UUID          `json:"schedule_id"`
+	StudioID   uuid.UUID          `json:"studio_id"`
+	Rows       int                `json:"rows"`
+	Columns    int                `json:"columns"`
+	Summary    map[string]int     `json:"summary"`
+	Seats      []SeatAvailability `json:"seats"`
+}
+
+// BookedSeat adalah kursi yang tercatat di reservasi aktif (PENDING belum expired / PAID)
+type BookedSeat struct {
+	SeatCode string
+	UserID   uuid.UUID
+	Status   string
+}
@@ -16,6 +16,9 @@ const (
 	StatusPaid     ReservationStatus = "PAID"
 	StatusCanceled ReservationStatus = "CANCELED"
 	StatusExpired  ReservationStatus = "EXPIRED"
+
+	StatusRefunded          ReservationStatus = "REFUNDED"
+	StatusPartiallyRefunded ReservationStatus = "PARTIALLY_REFUNDED"
 )
 
 // IsValidTransition checks if status transition is valid
@@ -24,14 +27,24 @@ func (r ReservationStatus) IsValidTransition(to ReservationStatus) bool {
 	case StatusPending:
 		return to == StatusPaid || to == StatusCanceled || to == StatusExpired
 	case StatusPaid:
-		return false // Paid reservations cannot be changed
-	case StatusCanceled, StatusExpired:
+		return to == StatusRefunded || to == StatusPartiallyRefunded
+	case StatusCanceled, StatusExpired, StatusRefunded, StatusPartiallyRefunded:
 		return false // Final states
 	default:
 		return false
 	}
 }
 
+// ReleasesSeats menandakan status di mana kursi reservasi kembali bisa dijual
+func (r ReservationStatus) ReleasesSeats() bool {
+	switch r {
+	case StatusCanceled, StatusExpired, StatusRefunded, StatusPartiallyRefunded:
+		return true
+	default:
+		return false
+	}
+}
+
 type Reservation struct {
 	ID         uuid.UUID         `gorm:"type:uuid;primaryKey" json:"id"`
 	UserID     uuid.UUID         `gorm:"type:uuid;not null" json:"user_id" binding:"required"`
@@ -73,11 +86,15 @@ func (r *Reservation) CanTransitionTo(newStatus ReservationStatus) bool {
 }
 
 type ReservationSeat struct {
-	ID            uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
-	ReservationID uuid.UUID `gorm:"type:uuid;not null" json:"reservation_id"`
-	SeatCode      string    `gorm:"type:varchar(10);not null" json:"seat_code"`
-	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	ReservationID uuid.UUID  `gorm:"type:uuid;not null" json:"reservation_id"`
+	ScheduleID    uuid.UUID  `gorm:"type:uuid;index" json:"schedule_id"`
+	SeatCode      string     `gorm:"type:varchar(10);not null" json:"seat_code"`
+	Category      string     `gorm:"type:varchar(20);not null;default:'regular'" json:"category"`
+	Price         int        `gorm:"not null;default:0" json:"price"`
+	ReleasedAt    *time.Time `json:"released_at,omitempty"` // terisi saat reservasi batal/expired, kursi boleh dipesan lagi
+	CreatedAt     time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
 
 	Reservation Reservation `gorm:"foreignKey:ReservationID;references:ID" json:"reservation"`
 }
@@ -1,12 +1,16 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
 	"movie-ticket/internal/middleware"
+	paymenterrors "movie-ticket/internal/payment_module/custom_errors"
+	customerrors "movie-ticket/internal/reservation_module/custom_errors"
 	"movie-ticket/internal/reservation_module/dto"
+	"movie-ticket/internal/reservation_module/entities"
 	service "movie-ticket/internal/reservation_module/services"
 
 	"github.com/gin-gonic/gin"
@@ -24,24 +28,32 @@ func NewReservationHandler(r *gin.RouterGroup, reservationService service.Reserv
 	r.PUT("/reservation/:id/cancel", h.CancelReservation)
 	r.GET("/reservation/:id", h.GetReservation)
 	r.GET("/reservation/history", h.GetHistory)
+	r.GET("/schedule/:id/seats", h.GetSeatAvailability)
+	r.POST("/reservation/:id/refund", h.RefundReservation)
+}
+
+func NewReservationHandlerAdmin(r *gin.RouterGroup, reservationService service.ReservationService) {
+	h := ReservationHandler{reservationService: reservationService}
+	r.POST("/reservation/:id/refund", h.AdminRefundReservation)
 }
 
 type CreateReservationRequest struct {
 	UserID     string   `json:"user_id" binding:"required"`
 	ScheduleID string   `json:"schedule_id" binding:"required"`
 	Seats      []string `json:"seats" binding:"required,min=1"`
-	TotalPrice int      `json:"total_price" binding:"required,min=1"`
+	TotalPrice int      `json:"total_price" binding:"omitempty,min=0"`
 }
 
 type ReservationResponse struct {
-	ID         string   `json:"id"`
-	UserID     string   `json:"user_id"`
-	ScheduleID string   `json:"schedule_id"`
-	Seats      []string `json:"seats"`
-	TotalPrice int      `json:"total_price"`
-	Status     string   `json:"status"`
-	ExpiresAt  string   `json:"expires_at"`
-	CreatedAt  string   `json:"created_at"`
+	ID         string              `json:"id"`
+	UserID     string              `json:"user_id"`
+	ScheduleID string              `json:"schedule_id"`
+	Seats      []string            `json:"seats"`
+	Items      []dto.PriceLineItem `json:"items"`
+	TotalPrice int                 `json:"total_price"`
+	Status     string              `json:"status"`
+	ExpiresAt  string              `json:"expires_at"`
+	CreatedAt  string              `json:"created_at"`
 }
 
 type ErrorResponse struct {
@@ -56,15 +68,17 @@ type SuccessResponse struct {
 
 // CreateReservation godoc
 // @Summary Membuat reservasi tiket baru
-// @Description Membuat reservasi tiket untuk jadwal dan kursi tertentu. Reservasi akan memiliki waktu expired untuk konfirmasi
+// @Description Membuat reservasi tiket untuk jadwal dan kursi tertentu. Total harga dihitung di server dari harga jadwal dan kategori kursi; total_price dari client opsional dan ditolak jika tidak sama. Reservasi akan memiliki waktu expired untuk konfirmasi
 // @Tags Reservations
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer token" default(Bearer <token>)
 // @Param request body dto.CreateReservationRequest true "Reservation creation data"
 // @Success 201 {object} SuccessResponse{data=ReservationResponse} "Reservation created successfully"
-// @Failure 400 {object} ErrorResponse "Bad Request - Validation error, invalid user ID, schedule ID, atau seats"
-// @Failure 409 {object} ErrorResponse "Conflict - Seats unavailable atau sudah diambil"
+// @Failure 400 {object} ErrorResponse "Bad Request - Validation error, invalid user ID, schedule ID, kursi tidak ada di studio, total harga tidak sesuai, atau jadwal sudah mulai"
+// @Failure 403 {object} ErrorResponse "Forbidden - Email belum diverifikasi (email_not_verified)"
+// @Failure 404 {object} ErrorResponse "Not Found - Jadwal tidak ditemukan"
+// @Failure 409 {object} ErrorResponse "Conflict - Kursi sedang di-hold (seats_unavailable) atau sudah terjual (seat_already_booked)"
 // @Failure 500 {object} ErrorResponse "Internal Server Error"
 // @Router /reservation/create [post]
 // @Security BearerAuth
@@ -117,9 +131,36 @@ func (h *ReservationHandler) CreateReservation(c *gin.Context) {
 		statusCode := http.StatusInternalServerError
 		errorType := "internal_error"
 
-		if strings.Contains(err.Error(), "seats required") {
+		if errors.Is(err, customerrors.ErrEmailNotVerified) {
+			statusCode = http.StatusForbidden
+			errorType = "email_not_verified"
+		} else if errors.Is(err, customerrors.ErrScheduleNotFound) {
+			statusCode = http.StatusNotFound
+			errorType = "schedule_not_found"
+		} else if errors.Is(err, customerrors.ErrScheduleAlreadyStarted) {
+			statusCode = http.StatusBadRequest
+			errorType = "schedule_already_started"
+		} else if strings.Contains(err.Error(), "seats required") {
 			statusCode = http.StatusBadRequest
 			errorType = "seats_required"
+		} else if errors.Is(err, customerrors.ErrSeatNotFound) {
+			statusCode = http.StatusBadRequest
+			errorType = "seat_not_found"
+		} else if errors.Is(err, customerrors.ErrSeatBlocked) {
+			statusCode = http.StatusConflict
+			errorType = "seats_unavailable"
+		} else if errors.Is(err, customerrors.ErrDuplicateSeat) {
+			statusCode = http.StatusBadRequest
+			errorType = "invalid_seats"
+		} else if errors.Is(err, customerrors.ErrPriceMismatch) {
+			statusCode = http.StatusBadRequest
+			errorType = "price_mismatch"
+		} else if errors.Is(err, customerrors.ErrSeatAlreadyBooked) {
+			statusCode = http.StatusConflict
+			errorType = "seat_already_booked"
+		} else if errors.Is(err, customerrors.ErrSeatOnHold) {
+			statusCode = http.StatusConflict
+			errorType = "seats_unavailable"
 		} else if strings.Contains(err.Error(), "invalid total price") {
 			statusCode = http.StatusBadRequest
 			errorType = "invalid_total_price"
@@ -138,38 +179,30 @@ func (h *ReservationHandler) CreateReservation(c *gin.Context) {
 		return
 	}
 
-	seatCodes := make([]string, 0, len(reservation.Seats))
-	for _, seat := range reservation.Seats {
-		seatCodes = append(seatCodes, seat.SeatCode)
-	}
-
 	c.JSON(http.StatusCreated, SuccessResponse{
 		Message: "Reservation created successfully",
-		Data: ReservationResponse{
-			ID:         reservation.ID.String(),
-			UserID:     reservation.UserID.String(),
-			ScheduleID: reservation.ScheduleID.String(),
-			Seats:      seatCodes,
-			TotalPrice: reservation.TotalPrice,
-			Status:     string(reservation.Status),
-			ExpiresAt:  reservation.ExpiresAt.Format("2006-01-02T15:04:05Z07:00"),
-			CreatedAt:  reservation.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		},
+		Data:    toReservationResponse(reservation),
 	})
 }
 
 // ConfirmReservation godoc
 // @Summary Konfirmasi reservasi tiket
-// @Description Mengkonfirmasi reservasi yang sebelumnya dibuat. Reservasi harus dalam status pending dan belum expired
+// @Description Membayar lalu mengkonfirmasi reservasi yang sebelumnya dibuat. Reservasi harus dalam status pending dan belum expired, dan status baru menjadi PAID setelah pembayaran berhasil di-capture
 // @Tags Reservations
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer token" default(Bearer <token>)
 // @Param id path string true "Reservation ID" format(uuid)
+// @Param request body dto.ConfirmReservationRequest false "Payment method (opsional)"
 // @Success 200 {object} SuccessResponse "Reservation confirmed successfully"
-// @Failure 400 {object} ErrorResponse "Bad Request - Invalid reservation ID, invalid status transition, atau reservation expired"
+// @Failure 400 {object} ErrorResponse "Bad Request - Invalid reservation ID, invalid status transition, reservation expired, atau jadwal sudah mulai"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Session tidak ditemukan"
+// @Failure 402 {object} ErrorResponse "Payment Required - Pembayaran ditolak"
+// @Failure 403 {object} ErrorResponse "Forbidden - Reservasi milik user lain"
 // @Failure 404 {object} ErrorResponse "Not Found - Reservation tidak ditemukan"
+// @Failure 409 {object} ErrorResponse "Conflict - Pembayaran untuk reservasi ini sedang/sudah diproses"
 // @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Failure 502 {object} ErrorResponse "Bad Gateway - Payment gateway gagal memproses pembayaran"
 // @Router /reservation/{id}/confirm [put]
 // @Security BearerAuth
 func (h *ReservationHandler) ConfirmReservation(c *gin.Context) {
@@ -182,11 +215,47 @@ func (h *ReservationHandler) ConfirmReservation(c *gin.Context) {
 		return
 	}
 
-	if err := h.reservationService.ConfirmReservation(c.Request.Context(), reservationID); err != nil {
+	userID, role, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User session not found",
+		})
+		return
+	}
+
+	// Body opsional, tanpa body payment method ditentukan oleh provider
+	var req dto.ConfirmReservationRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "validation_error",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	if err := h.reservationService.ConfirmReservation(c.Request.Context(), reservationID, userID, role, req.PaymentMethod); err != nil {
 		statusCode := http.StatusInternalServerError
 		errorType := "internal_error"
 
-		if strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, customerrors.ErrForbidden) {
+			statusCode = http.StatusForbidden
+			errorType = "forbidden"
+		} else if errors.Is(err, paymenterrors.ErrPaymentDeclined) {
+			statusCode = http.StatusPaymentRequired
+			errorType = "payment_declined"
+		} else if errors.Is(err, paymenterrors.ErrPaymentFailed) {
+			statusCode = http.StatusBadGateway
+			errorType = "payment_failed"
+		} else if errors.Is(err, paymenterrors.ErrPaymentActive) {
+			statusCode = http.StatusConflict
+			errorType = "payment_in_progress"
+		} else if errors.Is(err, customerrors.ErrScheduleAlreadyStarted) {
+			statusCode = http.StatusBadRequest
+			errorType = "schedule_already_started"
+		} else if strings.Contains(err.Error(), "not found") {
 			statusCode = http.StatusNotFound
 			errorType = "reservation_not_found"
 		} else if strings.Contains(err.Error(), "cannot confirm") {
@@ -219,6 +288,8 @@ func (h *ReservationHandler) ConfirmReservation(c *gin.Context) {
 // @Param id path string true "Reservation ID" format(uuid)
 // @Success 200 {object} SuccessResponse "Reservation canceled successfully"
 // @Failure 400 {object} ErrorResponse "Bad Request - Invalid reservation ID atau invalid status transition"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Session tidak ditemukan"
+// @Failure 403 {object} ErrorResponse "Forbidden - Reservasi milik user lain"
 // @Failure 404 {object} ErrorResponse "Not Found - Reservation tidak ditemukan"
 // @Failure 500 {object} ErrorResponse "Internal Server Error"
 // @Router /reservation/{id}/cancel [put]
@@ -233,11 +304,23 @@ func (h *ReservationHandler) CancelReservation(c *gin.Context) {
 		return
 	}
 
-	if err := h.reservationService.CancelReservation(c.Request.Context(), reservationID); err != nil {
+	userID, role, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User session not found",
+		})
+		return
+	}
+
+	if err := h.reservationService.CancelReservation(c.Request.Context(), reservationID, userID, role); err != nil {
 		statusCode := http.StatusInternalServerError
 		errorType := "internal_error"
 
-		if strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, customerrors.ErrForbidden) {
+			statusCode = http.StatusForbidden
+			errorType = "forbidden"
+		} else if strings.Contains(err.Error(), "not found") {
 			statusCode = http.StatusNotFound
 			errorType = "reservation_not_found"
 		} else if strings.Contains(err.Error(), "cannot cancel") {
@@ -267,6 +350,8 @@ func (h *ReservationHandler) CancelReservation(c *gin.Context) {
 // @Param id path string true "Reservation ID" format(uuid)
 // @Success 200 {object} SuccessResponse{data=ReservationResponse} "Reservation retrieved successfully"
 // @Failure 400 {object} ErrorResponse "Bad Request - Missing atau invalid reservation ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Session tidak ditemukan"
+// @Failure 403 {object} ErrorResponse "Forbidden - Reservasi milik user lain"
 // @Failure 404 {object} ErrorResponse "Not Found - Reservation tidak ditemukan"
 // @Failure 500 {object} ErrorResponse "Internal Server Error"
 // @Router /reservation/{id} [get]
@@ -290,12 +375,24 @@ func (h *ReservationHandler) GetReservation(c *gin.Context) {
 		return
 	}
 
-	reservation, err := h.reservationService.GetReservation(c.Request.Context(), reservationID)
+	userID, role, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User session not found",
+		})
+		return
+	}
+
+	reservation, err := h.reservationService.GetReservation(c.Request.Context(), reservationID, userID, role)
 	if err != nil {
 		statusCode := http.StatusInternalServerError
 		errorType := "internal_error"
 
-		if strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, customerrors.ErrForbidden) {
+			statusCode = http.StatusForbidden
+			errorType = "forbidden"
+		} else if strings.Contains(err.Error(), "not found") {
 			statusCode = http.StatusNotFound
 			errorType = "reservation_not_found"
 		}
@@ -307,23 +404,9 @@ func (h *ReservationHandler) GetReservation(c *gin.Context) {
 		return
 	}
 
-	seatCodes := make([]string, 0, len(reservation.Seats))
-	for _, seat := range reservation.Seats {
-		seatCodes = append(seatCodes, seat.SeatCode)
-	}
-
 	c.JSON(http.StatusOK, SuccessResponse{
 		Message: "Reservation retrieved successfully",
-		Data: ReservationResponse{
-			ID:         reservation.ID.String(),
-			UserID:     reservation.UserID.String(),
-			ScheduleID: reservation.ScheduleID.String(),
-			Seats:      seatCodes,
-			TotalPrice: reservation.TotalPrice,
-			Status:     string(reservation.Status),
-			ExpiresAt:  reservation.ExpiresAt.Format("2006-01-02T15:04:05Z07:00"),
-			CreatedAt:  reservation.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		},
+		Data:    toReservationResponse(reservation),
 	})
 }
 
@@ -355,3 +438,240 @@ func (h *ReservationHandler) GetHistory(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"data": history})
 }
+
+// GetSeatAvailability godoc
+// @Summary Mendapatkan ketersediaan kursi untuk jadwal
+// @Description Mengembalikan seluruh kursi pada denah studio jadwal beserta harga dan statusnya: available, held, held_by_me, sold, atau blocked
+// @Tags Reservations
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Schedule ID" format(uuid)
+// @Success 200 {object} SuccessResponse{data=dto.SeatAvailabilityResponse} "Seat availability retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Bad Request - Invalid schedule ID"
+// @Failure 404 {object} ErrorResponse "Not Found - Jadwal tidak ditemukan"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Router /schedule/{id}/seats [get]
+// @Security BearerAuth
+func (h *ReservationHandler) GetSeatAvailability(c *gin.Context) {
+	scheduleID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_schedule_id",
+			Message: "Invalid schedule ID format",
+		})
+		return
+	}
+
+	userID, err := middleware.GetUserIDFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_user_id",
+			Message: "Invalid user ID format",
+		})
+		return
+	}
+
+	availability, err := h.reservationService.GetSeatAvailability(c.Request.Context(), scheduleID, userID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		errorType := "internal_error"
+
+		if errors.Is(err, customerrors.ErrScheduleNotFound) {
+			statusCode = http.StatusNotFound
+			errorType = "schedule_not_found"
+		}
+
+		c.JSON(statusCode, ErrorResponse{
+			Error:   errorType,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Seat availability retrieved successfully",
+		Data:    availability,
+	})
+}
+
+// RefundReservation godoc
+// @Summary Refund reservasi yang sudah dibayar
+// @Description Membatalkan reservasi berstatus PAID dan mengembalikan dana sesuai kebijakan refund: penuh sampai REFUND_FULL_HOURS jam sebelum tayang, sebagian (REFUND_PARTIAL_PERCENT) setelahnya, dan tidak bisa setelah film mulai. Kursi dikembalikan ke inventory
+// @Tags Reservations
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Reservation ID" format(uuid)
+// @Success 200 {object} SuccessResponse{data=dto.RefundResponse} "Reservation refunded successfully"
+// @Failure 400 {object} ErrorResponse "Bad Request - Invalid reservation ID"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Session tidak ditemukan"
+// @Failure 403 {object} ErrorResponse "Forbidden - Reservasi milik user lain"
+// @Failure 404 {object} ErrorResponse "Not Found - Reservation tidak ditemukan"
+// @Failure 409 {object} ErrorResponse "Conflict - Reservasi tidak bisa di-refund"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Failure 502 {object} ErrorResponse "Bad Gateway - Payment gateway gagal memproses refund"
+// @Router /reservation/{id}/refund [post]
+// @Security BearerAuth
+func (h *ReservationHandler) RefundReservation(c *gin.Context) {
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_reservation_id",
+			Message: "Invalid reservation ID format",
+		})
+		return
+	}
+
+	userID, role, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User session not found",
+		})
+		return
+	}
+
+	result, err := h.reservationService.RefundReservation(c.Request.Context(), reservationID, userID, role)
+	if err != nil {
+		h.handleRefundError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Reservation refunded successfully",
+		Data:    result,
+	})
+}
+
+// AdminRefundReservation godoc
+// @Summary Refund reservasi oleh admin
+// @Description Admin dapat me-refund reservasi berstatus PAID tanpa mengikuti kebijakan refund. Amount opsional, default refund penuh; amount di bawah total menghasilkan status PARTIALLY_REFUNDED
+// @Tags Reservations
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Reservation ID" format(uuid)
+// @Param request body dto.AdminRefundRequest false "Nominal refund (opsional)"
+// @Success 200 {object} SuccessResponse{data=dto.RefundResponse} "Reservation refunded successfully"
+// @Failure 400 {object} ErrorResponse "Bad Request - Invalid reservation ID atau nominal refund"
+// @Failure 401 {object} ErrorResponse "Unauthorized - Session tidak ditemukan"
+// @Failure 403 {object} ErrorResponse "Forbidden - Hanya admin"
+// @Failure 404 {object} ErrorResponse "Not Found - Reservation tidak ditemukan"
+// @Failure 409 {object} ErrorResponse "Conflict - Reservasi tidak bisa di-refund"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Failure 502 {object} ErrorResponse "Bad Gateway - Payment gateway gagal memproses refund"
+// @Router /admin/reservation/{id}/refund [post]
+// @Security BearerAuth
+func (h *ReservationHandler) AdminRefundReservation(c *gin.Context) {
+	reservationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_reservation_id",
+			Message: "Invalid reservation ID format",
+		})
+		return
+	}
+
+	var req dto.AdminRefundRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "validation_error",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	_, role, ok := currentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User session not found",
+		})
+		return
+	}
+
+	result, err := h.reservationService.AdminRefundReservation(c.Request.Context(), reservationID, role, req.Amount)
+	if err != nil {
+		h.handleRefundError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Reservation refunded successfully",
+		Data:    result,
+	})
+}
+
+func (h *ReservationHandler) handleRefundError(c *gin.Context, err error) {
+	statusCode := http.StatusInternalServerError
+	errorType := "internal_error"
+
+	if errors.Is(err, customerrors.ErrForbidden) || errors.Is(err, customerrors.ErrUnauthorizedUser) {
+		statusCode = http.StatusForbidden
+		errorType = "forbidden"
+	} else if errors.Is(err, customerrors.ErrRefundNotAllowed) || errors.Is(err, customerrors.ErrInvalidStatusTransition) {
+		statusCode = http.StatusConflict
+		errorType = "refund_not_allowed"
+	} else if errors.Is(err, customerrors.ErrInvalidRefundAmount) || errors.Is(err, paymenterrors.ErrRefundExceeded) {
+		statusCode = http.StatusBadRequest
+		errorType = "invalid_refund_amount"
+	} else if errors.Is(err, paymenterrors.ErrRefundFailed) || errors.Is(err, paymenterrors.ErrPaymentNotFound) {
+		statusCode = http.StatusBadGateway
+		errorType = "refund_failed"
+	} else if strings.Contains(err.Error(), "not found") {
+		statusCode = http.StatusNotFound
+		errorType = "reservation_not_found"
+	}
+
+	c.JSON(statusCode, ErrorResponse{
+		Error:   errorType,
+		Message: err.Error(),
+	})
+}
+
+// currentUser mengambil user ID dan role yang di-set JwtMiddleware
+func currentUser(c *gin.Context) (uuid.UUID, string, bool) {
+	rawID, ok := c.Get(middleware.ContextKeyID)
+	if !ok {
+		return uuid.Nil, "", false
+	}
+	userID, ok := rawID.(uuid.UUID)
+	if !ok {
+		return uuid.Nil, "", false
+	}
+
+	role, ok := c.Get(middleware.ContextKeyRole)
+	if !ok {
+		return uuid.Nil, "", false
+	}
+	roleStr, ok := role.(string)
+	return userID, roleStr, ok
+}
+
+func toReservationResponse(reservation *entities.Reservation) ReservationResponse {
+	seatCodes := make([]string, 0, len(reservation.Seats))
+	items := make([]dto.PriceLineItem, 0, len(reservation.Seats))
+	for _, seat := range reservation.Seats {
+		seatCodes = append(seatCodes, seat.SeatCode)
+		items = append(items, dto.PriceLineItem{
+			SeatCode: seat.SeatCode,
+			Category: seat.Category,
+			Price:    seat.Price,
+		})
+	}
+
+	return ReservationResponse{
+		ID:         reservation.ID.String(),
+		UserID:     reservation.UserID.String(),
+		ScheduleID: reservation.ScheduleID.String(),
+		Seats:      seatCodes,
+		Items:      items,
+		TotalPrice: reservation.TotalPrice,
+		Status:     string(reservation.Status),
+		ExpiresAt:  reservation.ExpiresAt.Format("2006-01-02T15:04:05Z07:00"),
+		CreatedAt:  reservation.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
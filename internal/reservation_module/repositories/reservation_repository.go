@@ -2,21 +2,38 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	user "movie-ticket/internal/auth_module/entities"
+	customerrors "movie-ticket/internal/reservation_module/custom_errors"
 	"movie-ticket/internal/reservation_module/dto"
 	"movie-ticket/internal/reservation_module/entities"
+	schedule "movie-ticket/internal/schedule_module/entities"
+	studio "movie-ticket/internal/studio_module/entities"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 )
 
+// Kode error Postgres untuk pelanggaran unique constraint
+const uniqueViolationCode = "23505"
+
 type ReservationRepository interface {
-	Create(ctx context.Context, reservation *entities.Reservation, seats []string) error
-	UpdateStatus(ctx context.Context, reservationID uuid.UUID, status entities.ReservationStatus) error
+	Create(ctx context.Context, reservation *entities.Reservation, seats []entities.ReservationSeat) error
+	UpdateStatus(ctx context.Context, reservationID uuid.UUID, from, to entities.ReservationStatus) error
+	// UpdateStatusWith menjalankan fn setelah status diklaim, di dalam transaksi yang sama
+	UpdateStatusWith(ctx context.Context, reservationID uuid.UUID, from, to entities.ReservationStatus, fn func() error) error
 	FindByID(ctx context.Context, id uuid.UUID) (*entities.Reservation, error)
 	FindExpiredReservations(ctx context.Context) ([]*entities.Reservation, error)
 	HistoryReservations(ctx context.Context, userID uuid.UUID) ([]*dto.ReservationHistory, error)
-	UpdateExpiredReservations(ctx context.Context) error
+	UpdateExpiredReservations(ctx context.Context, reservationIDs []uuid.UUID) ([]uuid.UUID, error)
+	FindSchedule(ctx context.Context, scheduleID uuid.UUID) (*schedule.Schedules, error)
+	IsEmailVerified(ctx context.Context, userID uuid.UUID) (bool, error)
+	FindStudioSeats(ctx context.Context, studioID uuid.UUID, codes []string) ([]studio.StudioSeat, error)
+	FindStudioLayout(ctx context.Context, studioID uuid.UUID) ([]studio.StudioSeat, error)
+	FindBookedSeats(ctx context.Context, scheduleID uuid.UUID) ([]dto.BookedSeat, error)
 }
 
 type reservationRepository struct {
@@ -27,24 +44,38 @@ func NewReservationRepository(db *gorm.DB) ReservationRepository {
 	return &reservationRepository{db: db}
 }
 
-func (r *reservationRepository) Create(ctx context.Context, reservation *entities.Reservation, seats []string) error {
+func (r *reservationRepository) Create(ctx context.Context, reservation *entities.Reservation, seats []entities.ReservationSeat) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		seatCodes := make([]string, 0, len(seats))
+		for _, seat := range seats {
+			seatCodes = append(seatCodes, seat.SeatCode)
+		}
+
+		// Reservasi pending yang sudah lewat batas waktu tidak boleh menahan kursi
+		if len(seatCodes) > 0 {
+			if err := expireLapsedHolds(tx, reservation.ScheduleID, seatCodes); err != nil {
+				return err
+			}
+		}
+
 		// Create reservation
 		if err := tx.Create(reservation).Error; err != nil {
 			return err
 		}
 
 		// Create seat entities
-		var seatEntities []entities.ReservationSeat
+		seatEntities := make([]entities.ReservationSeat, 0, len(seats))
 		for _, seat := range seats {
-			seatEntities = append(seatEntities, entities.ReservationSeat{
-				ReservationID: reservation.ID,
-				SeatCode:      seat,
-			})
+			seat.ReservationID = reservation.ID
+			seat.ScheduleID = reservation.ScheduleID
+			seatEntities = append(seatEntities, seat)
 		}
 
 		if len(seatEntities) > 0 {
 			if err := tx.Create(&seatEntities).Error; err != nil {
+				if isUniqueViolation(err) {
+					return fmt.Errorf("%w: %v", customerrors.ErrSeatAlreadyBooked, err)
+				}
 				return err
 			}
 		}
@@ -53,25 +84,87 @@ func (r *reservationRepository) Create(ctx context.Context, reservation *entitie
 	})
 }
 
-func (r *reservationRepository) UpdateStatus(ctx context.Context, reservationID uuid.UUID, status entities.ReservationStatus) error {
-	result := r.db.WithContext(ctx).Model(&entities.Reservation{}).
-		Where("id = ?", reservationID).
-		Update("status", status)
+// expireLapsedHolds menandai reservasi pending yang sudah expired dan memegang
+// salah satu kursi sebagai EXPIRED, lalu melepas kursinya
+func expireLapsedHolds(tx *gorm.DB, scheduleID uuid.UUID, seatCodes []string) error {
+	now := time.Now()
+
+	lapsed := tx.Model(&entities.ReservationSeat{}).
+		Select("reservation_id").
+		Where("schedule_id = ? AND seat_code IN ? AND released_at IS NULL", scheduleID, seatCodes)
 
-	if result.Error != nil {
-		return result.Error
+	var ids []uuid.UUID
+	err := tx.Model(&entities.Reservation{}).
+		Where("status = ? AND expires_at < ? AND id IN (?)", entities.StatusPending, now, lapsed).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return err
 	}
 
-	if result.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := tx.Model(&entities.Reservation{}).
+		Where("id IN ? AND status = ?", ids, entities.StatusPending).
+		Update("status", entities.StatusExpired).Error; err != nil {
+		return err
 	}
 
-	return nil
+	return releaseSeats(tx, ids, now)
+}
+
+// releaseSeats melepas kursi milik reservasi agar bisa dipesan lagi
+func releaseSeats(tx *gorm.DB, reservationIDs []uuid.UUID, at time.Time) error {
+	return tx.Model(&entities.ReservationSeat{}).
+		Where("reservation_id IN ? AND released_at IS NULL", reservationIDs).
+		Update("released_at", at).Error
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
+
+// UpdateStatus hanya mengubah status jika status saat ini masih from, sehingga dua request
+// yang bersamaan tidak bisa sama-sama melakukan transisi yang sama
+func (r *reservationRepository) UpdateStatus(ctx context.Context, reservationID uuid.UUID, from, to entities.ReservationStatus) error {
+	return r.UpdateStatusWith(ctx, reservationID, from, to, nil)
+}
+
+// UpdateStatusWith mengunci baris reservasi lewat update bersyarat lalu menjalankan fn. Jika fn gagal,
+// status dan kursi kembali seperti semula; request lain yang menunggu lock mendapat ErrInvalidStatusTransition.
+func (r *reservationRepository) UpdateStatusWith(ctx context.Context, reservationID uuid.UUID, from, to entities.ReservationStatus, fn func() error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.Reservation{}).
+			Where("id = ? AND status = ?", reservationID, from).
+			Update("status", to)
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: reservation is no longer %s", customerrors.ErrInvalidStatusTransition, from)
+		}
+
+		if to.ReleasesSeats() {
+			if err := releaseSeats(tx, []uuid.UUID{reservationID}, time.Now()); err != nil {
+				return err
+			}
+		}
+
+		if fn != nil {
+			return fn()
+		}
+
+		return nil
+	})
 }
 
 func (r *reservationRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.Reservation, error) {
 	var reservation entities.Reservation
-	if err := r.db.WithContext(ctx).Preload("Seats").First(&reservation, "id = ?", id).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Seats").Preload("Schedule").First(&reservation, "id = ?", id).Error; err != nil {
 		return nil, err
 	}
 	return &reservation, nil
@@ -87,11 +180,98 @@ func (r *reservationRepository) FindExpiredReservations(ctx context.Context) ([]
 	return reservations, err
 }
 
-func (r *reservationRepository) UpdateExpiredReservations(ctx context.Context) error {
-	return r.db.WithContext(ctx).
-		Model(&entities.Reservation{}).
-		Where("status = ? AND expires_at < ?", entities.StatusPending, time.Now()).
-		Update("status", entities.StatusExpired).Error
+// UpdateExpiredReservations meng-expire reservasi berdasarkan ID. Hanya yang masih
+// PENDING dan sudah lewat batas waktu yang diubah; ID yang benar-benar di-expire dikembalikan.
+func (r *reservationRepository) UpdateExpiredReservations(ctx context.Context, reservationIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var expired []uuid.UUID
+	if len(reservationIDs) == 0 {
+		return expired, nil
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		query := `
+			UPDATE reservations SET status = ?, updated_at = ?
+			WHERE id IN ? AND status = ? AND expires_at < ?
+			RETURNING id;
+		`
+		if err := tx.Raw(query, entities.StatusExpired, now, reservationIDs, entities.StatusPending, now).
+			Scan(&expired).Error; err != nil {
+			return err
+		}
+
+		if len(expired) == 0 {
+			return nil
+		}
+
+		return releaseSeats(tx, expired, now)
+	})
+
+	return expired, err
+}
+
+// FindSchedule mengembalikan nil jika jadwal tidak ditemukan
+func (r *reservationRepository) FindSchedule(ctx context.Context, scheduleID uuid.UUID) (*schedule.Schedules, error) {
+	var s schedule.Schedules
+	err := r.db.WithContext(ctx).First(&s, "id = ?", scheduleID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *reservationRepository) IsEmailVerified(ctx context.Context, userID uuid.UUID) (bool, error) {
+	var u user.User
+	err := r.db.WithContext(ctx).Select("id", "email_verified_at").First(&u, "id = ?", userID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return u.EmailVerifiedAt != nil, nil
+}
+
+func (r *reservationRepository) FindStudioSeats(ctx context.Context, studioID uuid.UUID, codes []string) ([]studio.StudioSeat, error) {
+	var seats []studio.StudioSeat
+	err := r.db.WithContext(ctx).
+		Where("studio_id = ? AND code IN ?", studioID, codes).
+		Find(&seats).Error
+
+	return seats, err
+}
+
+func (r *reservationRepository) FindStudioLayout(ctx context.Context, studioID uuid.UUID) ([]studio.StudioSeat, error) {
+	var seats []studio.StudioSeat
+	err := r.db.WithContext(ctx).
+		Where("studio_id = ?", studioID).
+		Order("row_index ASC, column_index ASC").
+		Find(&seats).Error
+
+	return seats, err
+}
+
+func (r *reservationRepository) FindBookedSeats(ctx context.Context, scheduleID uuid.UUID) ([]dto.BookedSeat, error) {
+	var seats []dto.BookedSeat
+
+	query := `
+		SELECT rs.seat_code, r.user_id, r.status
+		FROM reservation_seats rs
+		JOIN reservations r ON rs.reservation_id = r.id
+		WHERE r.schedule_id = ?
+		  AND rs.released_at IS NULL
+		  AND (r.status = ? OR (r.status = ? AND r.expires_at > ?));
+	`
+
+	err := r.db.WithContext(ctx).
+		Raw(query, scheduleID, entities.StatusPaid, entities.StatusPending, time.Now()).
+		Scan(&seats).Error
+
+	return seats, err
 }
 
 func (r *reservationRepository) HistoryReservations(ctx context.Context, userID uuid.UUID) ([]*dto.ReservationHistory, error) {
@@ -124,7 +304,7 @@ func (r *reservationRepository) HistoryReservations(ctx context.Context, userID
 		JOIN movies m ON s.movie_id = m.id
 		JOIN studios st ON s.studio_id = st.id
 		WHERE r.user_id = ? 
-		  AND r.status IN ('PENDING', 'CANCEL', 'PAID')
+		  AND r.status IN ('PENDING', 'CANCEL', 'PAID', 'REFUNDED', 'PARTIALLY_REFUNDED')
 		ORDER BY r.created_at DESC;
 	`
 
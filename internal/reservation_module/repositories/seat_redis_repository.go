@@ -3,17 +3,53 @@ package repository
 import (
 	"context"
 	"fmt"
+	customerrors "movie-ticket/internal/reservation_module/custom_errors"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
+// SeatHold adalah isi satu field di hash reservation:<schedule>.
+// Disimpan sebagai "<reservationID>|<userID>|<expiresAtMs>" sehingga
+// setiap kursi punya pemilik dan waktu expired sendiri.
+type SeatHold struct {
+	ReservationID string
+	UserID        string
+	ExpiresAt     time.Time
+}
+
+func (h SeatHold) IsExpired(now time.Time) bool {
+	return !now.Before(h.ExpiresAt)
+}
+
+func parseSeatHold(value string) (SeatHold, bool) {
+	parts := strings.Split(value, "|")
+	if len(parts) != 3 {
+		return SeatHold{}, false
+	}
+
+	expiresAtMs, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return SeatHold{}, false
+	}
+
+	return SeatHold{
+		ReservationID: parts[0],
+		UserID:        parts[1],
+		ExpiresAt:     time.UnixMilli(expiresAtMs),
+	}, true
+}
+
 type SeatRedisRepository interface {
-	HoldSeats(ctx context.Context, scheduleID string, userID string, seats []string, ttl time.Duration) error
-	ReleaseSeats(ctx context.Context, scheduleID string, seats []string) error
+	HoldSeats(ctx context.Context, scheduleID string, hold SeatHold, seats []string) error
+	ReleaseSeats(ctx context.Context, scheduleID string, reservationID string, seats []string) error
 	IsSeatAvailable(ctx context.Context, scheduleID string, seat string) (bool, error)
-	ConfirmSeats(ctx context.Context, scheduleID string, seats []string) error
-	GetLockedSeats(ctx context.Context, scheduleID string) (map[string]string, error)
+	ConfirmSeats(ctx context.Context, scheduleID string, hold SeatHold, seats []string) error
+	ReleaseConfirmedSeats(ctx context.Context, scheduleID string, reservationID string, seats []string) error
+	GetLockedSeats(ctx context.Context, scheduleID string) (map[string]SeatHold, error)
+	GetConfirmedSeats(ctx context.Context, scheduleID string) (map[string]string, error)
 }
 
 type seatRedisRepository struct {
@@ -24,108 +60,177 @@ func NewSeatRedisRepository(r *redis.Client) SeatRedisRepository {
 	return &seatRedisRepository{redis: r}
 }
 
-// HoldSeats menggunakan Lua script untuk atomic operation
-func (r *seatRedisRepository) HoldSeats(ctx context.Context, scheduleID string, userID string, seats []string, ttl time.Duration) error {
+// Script Lua di bawah mem-parse field hold dengan pola yang sama:
+// reservationID|userID|expiresAtMs. Hold yang sudah lewat expiresAtMs dianggap kosong.
+const luaParseHold = `
+	local function parseHold(value)
+		if not value then
+			return nil
+		end
+		local resID, userID, expiresAt = string.match(value, '^([^|]*)|([^|]*)|(%d+)$')
+		if not resID then
+			return nil
+		end
+		return resID, userID, tonumber(expiresAt)
+	end
+`
+
+// HoldSeats menggunakan Lua script untuk atomic operation.
+// Kursi yang sudah terjual (confirmed:<schedule>) maupun sedang di-hold akan ditolak.
+// Setiap kursi expired sendiri-sendiri sesuai hold.ExpiresAt; TTL key hanya
+// diperpanjang, tidak pernah diperpendek, agar hold lain tidak ikut hilang.
+func (r *seatRedisRepository) HoldSeats(ctx context.Context, scheduleID string, hold SeatHold, seats []string) error {
 	key := fmt.Sprintf("reservation:%s", scheduleID)
+	confirmKey := fmt.Sprintf("confirmed:%s", scheduleID)
 
-	// Lua script untuk atomic check and set
-	luaScript := `
+	luaScript := luaParseHold + `
 		local key = KEYS[1]
-		local ttl = ARGV[1]
-		local userID = ARGV[2]
-		
-		-- Check if any seats are already taken
-		for i = 3, #ARGV do
+		local confirmKey = KEYS[2]
+		local value = ARGV[1]
+		local now = tonumber(ARGV[2])
+		local expiresAt = tonumber(ARGV[3])
+
+		-- Check if any seats are already sold or held by an active reservation
+		for i = 4, #ARGV do
 			local seat = ARGV[i]
-			if redis.call('HEXISTS', key, seat) == 1 then
-				return {err = 'seat ' .. seat .. ' already taken'}
+			if redis.call('HEXISTS', confirmKey, seat) == 1 then
+				return redis.error_reply('BOOKED ' .. seat)
+			end
+			local _, _, heldUntil = parseHold(redis.call('HGET', key, seat))
+			if heldUntil and heldUntil > now then
+				return redis.error_reply('HELD ' .. seat)
 			end
 		end
-		
+
 		-- Set all seats atomically
 		local data = {}
-		for i = 3, #ARGV do
-			local seat = ARGV[i]
-			table.insert(data, seat)
-			table.insert(data, userID)
+		for i = 4, #ARGV do
+			table.insert(data, ARGV[i])
+			table.insert(data, value)
+		end
+		redis.call('HSET', key, unpack(data))
+
+		local pttl = redis.call('PTTL', key)
+		if pttl < 0 or now + pttl < expiresAt then
+			redis.call('PEXPIREAT', key, expiresAt)
 		end
-		
-		redis.call('HMSET', key, unpack(data))
-		redis.call('EXPIRE', key, ttl)
-		
-		return {ok = 'success'}
+
+		return 'OK'
 	`
 
-	args := make([]interface{}, 0, len(seats)+2)
-	args = append(args, int(ttl.Seconds()), userID)
+	expiresAtMs := hold.ExpiresAt.UnixMilli()
+	value := fmt.Sprintf("%s|%s|%d", hold.ReservationID, hold.UserID, expiresAtMs)
+
+	args := make([]interface{}, 0, len(seats)+3)
+	args = append(args, value, time.Now().UnixMilli(), expiresAtMs)
 	for _, seat := range seats {
 		args = append(args, seat)
 	}
 
-	result, err := r.redis.Eval(ctx, luaScript, []string{key}, args...).Result()
+	err := r.redis.Eval(ctx, luaScript, []string{key, confirmKey}, args...).Err()
 	if err != nil {
-		return err
-	}
-
-	// Check result
-	if resultMap, ok := result.(map[interface{}]interface{}); ok {
-		if errMsg, exists := resultMap["err"]; exists {
-			return fmt.Errorf("%v", errMsg)
-		}
+		return holdError(err)
 	}
 
 	return nil
 }
 
-func (r *seatRedisRepository) ReleaseSeats(ctx context.Context, scheduleID string, seats []string) error {
+// holdError menerjemahkan error dari script hold ke custom error
+func holdError(err error) error {
+	if seat, ok := strings.CutPrefix(err.Error(), "BOOKED "); ok {
+		return fmt.Errorf("%w: seat %s already booked", customerrors.ErrSeatAlreadyBooked, seat)
+	}
+	if seat, ok := strings.CutPrefix(err.Error(), "HELD "); ok {
+		return fmt.Errorf("%w: seat %s already taken", customerrors.ErrSeatOnHold, seat)
+	}
+	return err
+}
+
+// ReleaseSeats hanya menghapus hold milik reservationID, hold reservasi lain dibiarkan
+func (r *seatRedisRepository) ReleaseSeats(ctx context.Context, scheduleID string, reservationID string, seats []string) error {
 	key := fmt.Sprintf("reservation:%s", scheduleID)
 
 	if len(seats) == 0 {
 		return nil
 	}
 
-	fields := make([]string, len(seats))
-	for i, seat := range seats {
-		fields[i] = seat
+	luaScript := luaParseHold + `
+		local key = KEYS[1]
+		local reservationID = ARGV[1]
+
+		local released = 0
+		for i = 2, #ARGV do
+			local owner = parseHold(redis.call('HGET', key, ARGV[i]))
+			if owner == reservationID then
+				redis.call('HDEL', key, ARGV[i])
+				released = released + 1
+			end
+		end
+
+		return released
+	`
+
+	args := make([]interface{}, 0, len(seats)+1)
+	args = append(args, reservationID)
+	for _, seat := range seats {
+		args = append(args, seat)
 	}
 
-	return r.redis.HDel(ctx, key, fields...).Err()
+	return r.redis.Eval(ctx, luaScript, []string{key}, args...).Err()
 }
 
 func (r *seatRedisRepository) IsSeatAvailable(ctx context.Context, scheduleID string, seat string) (bool, error) {
 	key := fmt.Sprintf("reservation:%s", scheduleID)
+	confirmKey := fmt.Sprintf("confirmed:%s", scheduleID)
 
-	exists, err := r.redis.HExists(ctx, key, seat).Result()
+	sold, err := r.redis.HExists(ctx, confirmKey, seat).Result()
 	if err != nil {
 		return false, err
 	}
+	if sold {
+		return false, nil
+	}
 
-	return !exists, nil
+	value, err := r.redis.HGet(ctx, key, seat).Result()
+	if err == redis.Nil {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	hold, ok := parseSeatHold(value)
+	return !ok || hold.IsExpired(time.Now()), nil
 }
 
-func (r *seatRedisRepository) ConfirmSeats(ctx context.Context, scheduleID string, seats []string) error {
+// ConfirmSeats memindahkan kursi dari hold ke confirmed:<schedule>.
+// Kursi yang sedang di-hold reservasi lain tidak ikut dipindahkan.
+func (r *seatRedisRepository) ConfirmSeats(ctx context.Context, scheduleID string, hold SeatHold, seats []string) error {
 	tempKey := fmt.Sprintf("reservation:%s", scheduleID)
 	confirmKey := fmt.Sprintf("confirmed:%s", scheduleID)
 
-	luaScript := `
+	luaScript := luaParseHold + `
 		local tempKey = KEYS[1]
 		local confirmKey = KEYS[2]
+		local reservationID = ARGV[1]
+		local owner = ARGV[2]
+		local now = tonumber(ARGV[3])
 
 		local data = {}
 		local count = 0
-		for i = 1, #ARGV do
+		for i = 4, #ARGV do
 			local seat = ARGV[i]
-			local userID = redis.call('HGET', tempKey, seat)
-			if userID then
+			local resID, _, heldUntil = parseHold(redis.call('HGET', tempKey, seat))
+			if resID == reservationID or not heldUntil or heldUntil <= now then
 				table.insert(data, seat)
-				table.insert(data, userID)
+				table.insert(data, owner)
 				redis.call('HDEL', tempKey, seat)
 				count = count + 1
 			end
 		end
 
 		if #data > 0 then
-			redis.call('HMSET', confirmKey, unpack(data))
+			redis.call('HSET', confirmKey, unpack(data))
 		end
 
 		-- kalau sudah tidak ada field, hapus key reservation:<schedule_id>
@@ -136,16 +241,60 @@ func (r *seatRedisRepository) ConfirmSeats(ctx context.Context, scheduleID strin
 		return count
 	`
 
-	args := make([]interface{}, len(seats))
-	for i, seat := range seats {
-		args[i] = seat
+	args := make([]interface{}, 0, len(seats)+3)
+	args = append(args, hold.ReservationID, hold.ReservationID+"|"+hold.UserID, time.Now().UnixMilli())
+	for _, seat := range seats {
+		args = append(args, seat)
+	}
+
+	count, err := r.redis.Eval(ctx, luaScript, []string{tempKey, confirmKey}, args...).Int()
+	if err != nil {
+		return err
 	}
 
-	_, err := r.redis.Eval(ctx, luaScript, []string{tempKey, confirmKey}, args...).Result()
-	return err
+	if count < len(seats) {
+		return fmt.Errorf("%w: %d of %d seats held by another reservation", customerrors.ErrSeatOnHold, len(seats)-count, len(seats))
+	}
+
+	return nil
 }
 
-func (r *seatRedisRepository) GetLockedSeats(ctx context.Context, scheduleID string) (map[string]string, error) {
+// ReleaseConfirmedSeats mengembalikan kursi terjual ke inventory (misal setelah refund).
+// Field confirmed:<schedule> berisi "<reservationID>|<userID>", hanya milik reservationID yang dihapus.
+func (r *seatRedisRepository) ReleaseConfirmedSeats(ctx context.Context, scheduleID string, reservationID string, seats []string) error {
+	confirmKey := fmt.Sprintf("confirmed:%s", scheduleID)
+
+	if len(seats) == 0 {
+		return nil
+	}
+
+	luaScript := `
+		local confirmKey = KEYS[1]
+		local prefix = ARGV[1] .. '|'
+
+		local released = 0
+		for i = 2, #ARGV do
+			local value = redis.call('HGET', confirmKey, ARGV[i])
+			if value and string.sub(value, 1, #prefix) == prefix then
+				redis.call('HDEL', confirmKey, ARGV[i])
+				released = released + 1
+			end
+		end
+
+		return released
+	`
+
+	args := make([]interface{}, 0, len(seats)+1)
+	args = append(args, reservationID)
+	for _, seat := range seats {
+		args = append(args, seat)
+	}
+
+	return r.redis.Eval(ctx, luaScript, []string{confirmKey}, args...).Err()
+}
+
+// GetLockedSeats mengembalikan hold yang masih aktif, hold yang sudah expired dilewati
+func (r *seatRedisRepository) GetLockedSeats(ctx context.Context, scheduleID string) (map[string]SeatHold, error) {
 	key := fmt.Sprintf("reservation:%s", scheduleID)
 
 	result, err := r.redis.HGetAll(ctx, key).Result()
@@ -153,5 +302,26 @@ func (r *seatRedisRepository) GetLockedSeats(ctx context.Context, scheduleID str
 		return nil, err
 	}
 
+	now := time.Now()
+	holds := make(map[string]SeatHold, len(result))
+	for seat, value := range result {
+		hold, ok := parseSeatHold(value)
+		if !ok || hold.IsExpired(now) {
+			continue
+		}
+		holds[seat] = hold
+	}
+
+	return holds, nil
+}
+
+func (r *seatRedisRepository) GetConfirmedSeats(ctx context.Context, scheduleID string) (map[string]string, error) {
+	key := fmt.Sprintf("confirmed:%s", scheduleID)
+
+	result, err := r.redis.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+
 	return result, nil
 }
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"fmt"
+	"movie-ticket/config"
+	customerrors "movie-ticket/internal/reservation_module/custom_errors"
+	"movie-ticket/internal/reservation_module/dto"
+	"strconv"
+	"strings"
+)
+
+// Kategori kursi yang dikenal oleh pricing engine
+const (
+	SeatCategoryRegular    = "regular"
+	SeatCategoryVIP        = "vip"
+	SeatCategorySweetbox   = "sweetbox"
+	SeatCategoryWheelchair = "wheelchair"
+)
+
+// Persentase harga per kategori terhadap harga dasar jadwal.
+// Bisa di-override lewat env PRICE_PERCENT_<KATEGORI>, misal PRICE_PERCENT_VIP=175
+var defaultCategoryPercent = map[string]int{
+	SeatCategoryRegular:    100,
+	SeatCategoryVIP:        150,
+	SeatCategorySweetbox:   200,
+	SeatCategoryWheelchair: 100,
+}
+
+// SeatPricingInput adalah kursi yang akan dihitung harganya
+type SeatPricingInput struct {
+	SeatCode string
+	Category string
+}
+
+type PricingEngine interface {
+	Quote(basePrice int, seats []SeatPricingInput) (*dto.PriceQuote, error)
+}
+
+type pricingEngine struct {
+	categoryPercent map[string]int
+}
+
+func NewPricingEngine() PricingEngine {
+	percent := make(map[string]int, len(defaultCategoryPercent))
+	for category, value := range defaultCategoryPercent {
+		percent[category] = value
+
+		envKey := "PRICE_PERCENT_" + strings.ToUpper(category)
+		if raw := config.Get(envKey); raw != "" {
+			if parsed, err := strconv.Atoi(raw); err == nil && parsed > 0 {
+				percent[category] = parsed
+			}
+		}
+	}
+
+	return &pricingEngine{categoryPercent: percent}
+}
+
+// Quote menghitung total harga dari harga dasar jadwal, jumlah kursi, dan kategori kursi
+func (e *pricingEngine) Quote(basePrice int, seats []SeatPricingInput) (*dto.PriceQuote, error) {
+	if basePrice <= 0 {
+		return nil, fmt.Errorf("%w: schedule price must be greater than zero", customerrors.ErrInvalidPrice)
+	}
+
+	if len(seats) == 0 {
+		return nil, fmt.Errorf("%w", customerrors.ErrSeatsRequired)
+	}
+
+	quote := &dto.PriceQuote{
+		BasePrice: basePrice,
+		Items:     make([]dto.PriceLineItem, 0, len(seats)),
+	}
+
+	for _, seat := range seats {
+		category := seat.Category
+		if category == "" {
+			category = SeatCategoryRegular
+		}
+
+		percent, ok := e.categoryPercent[category]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown seat category %s", customerrors.ErrInvalidPrice, category)
+		}
+
+		price := basePrice * percent / 100
+		quote.Items = append(quote.Items, dto.PriceLineItem{
+			SeatCode: seat.SeatCode,
+			Category: category,
+			Price:    price,
+		})
+		quote.Total += price
+	}
+
+	quote.SeatCount = len(quote.Items)
+
+	return quote, nil
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"fmt"
+	"movie-ticket/config"
+	customerrors "movie-ticket/internal/reservation_module/custom_errors"
+	"strconv"
+	"time"
+)
+
+// Default kebijakan refund: penuh sampai 24 jam sebelum tayang, 50% setelahnya,
+// dan tidak ada refund setelah film mulai. Bisa diatur lewat env
+// REFUND_FULL_HOURS dan REFUND_PARTIAL_PERCENT.
+const (
+	defaultRefundFullHours      = 24
+	defaultRefundPartialPercent = 50
+)
+
+type RefundPolicy interface {
+	// Amount menghitung nominal refund untuk total yang sudah dibayar
+	Amount(totalPaid int, showtime time.Time, now time.Time) (int, error)
+}
+
+type refundPolicy struct {
+	fullWindow     time.Duration
+	partialPercent int
+}
+
+func NewRefundPolicy() RefundPolicy {
+	policy := &refundPolicy{
+		fullWindow:     defaultRefundFullHours * time.Hour,
+		partialPercent: defaultRefundPartialPercent,
+	}
+
+	if raw := config.Get("REFUND_FULL_HOURS"); raw != "" {
+		if hours, err := strconv.Atoi(raw); err == nil && hours >= 0 {
+			policy.fullWindow = time.Duration(hours) * time.Hour
+		}
+	}
+
+	if raw := config.Get("REFUND_PARTIAL_PERCENT"); raw != "" {
+		if percent, err := strconv.Atoi(raw); err == nil && percent >= 0 && percent <= 100 {
+			policy.partialPercent = percent
+		}
+	}
+
+	return policy
+}
+
+func (p *refundPolicy) Amount(totalPaid int, showtime time.Time, now time.Time) (int, error) {
+	if !now.Before(showtime) {
+		return 0, fmt.Errorf("%w: schedule already started", customerrors.ErrRefundNotAllowed)
+	}
+
+	if !now.After(showtime.Add(-p.fullWindow)) {
+		return totalPaid, nil
+	}
+
+	amount := totalPaid * p.partialPercent / 100
+	if amount <= 0 {
+		return 0, fmt.Errorf("%w: less than %s before showtime", customerrors.ErrRefundNotAllowed, p.fullWindow)
+	}
+
+	return amount, nil
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"errors"
+	customerrors "movie-ticket/internal/reservation_module/custom_errors"
+	"testing"
+	"time"
+)
+
+func TestRefundPolicyAmount(t *testing.T) {
+	policy := &refundPolicy{fullWindow: 24 * time.Hour, partialPercent: 50}
+	showtime := time.Date(2026, 3, 10, 19, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		paid    int
+		now     time.Time
+		want    int
+		wantErr bool
+	}{
+		{"jauh sebelum tayang", 100000, showtime.Add(-72 * time.Hour), 100000, false},
+		{"tepat batas refund penuh", 100000, showtime.Add(-24 * time.Hour), 100000, false},
+		{"setelah batas refund penuh", 100000, showtime.Add(-24*time.Hour + time.Second), 50000, false},
+		{"sesaat sebelum tayang", 75001, showtime.Add(-time.Minute), 37500, false},
+		{"tepat jam tayang", 100000, showtime, 0, true},
+		{"sudah tayang", 100000, showtime.Add(time.Hour), 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := policy.Amount(tt.paid, showtime, tt.now)
+			if tt.wantErr {
+				if !errors.Is(err, customerrors.ErrRefundNotAllowed) {
+					t.Fatalf("error = %v, want ErrRefundNotAllowed", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Amount = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefundPolicyWithoutPartialRefund(t *testing.T) {
+	policy := &refundPolicy{fullWindow: 24 * time.Hour, partialPercent: 0}
+	showtime := time.Date(2026, 3, 10, 19, 0, 0, 0, time.UTC)
+
+	if _, err := policy.Amount(100000, showtime, showtime.Add(-time.Hour)); !errors.Is(err, customerrors.ErrRefundNotAllowed) {
+		t.Errorf("error = %v, want ErrRefundNotAllowed", err)
+	}
+}
+
+func TestNewRefundPolicyFromEnv(t *testing.T) {
+	t.Setenv("REFUND_FULL_HOURS", "48")
+	t.Setenv("REFUND_PARTIAL_PERCENT", "150")
+
+	policy := NewRefundPolicy().(*refundPolicy)
+	if policy.fullWindow != 48*time.Hour {
+		t.Errorf("fullWindow = %s, want 48h", policy.fullWindow)
+	}
+	if policy.partialPercent != defaultRefundPartialPercent {
+		t.Errorf("partialPercent = %d, want default %d", policy.partialPercent, defaultRefundPartialPercent)
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	payment "movie-ticket/internal/payment_module/entities"
 	customerrors "movie-ticket/internal/reservation_module/custom_errors"
 	"movie-ticket/internal/reservation_module/dto"
 	"movie-ticket/internal/reservation_module/entities"
 	repository "movie-ticket/internal/reservation_module/repositories"
+	studio "movie-ticket/internal/studio_module/entities"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,22 +18,42 @@ import (
 
 type ReservationService interface {
 	CreateReservation(ctx context.Context, userID uuid.UUID, scheduleID uuid.UUID, seats []string, totalPrice int) (*entities.Reservation, error)
-	ConfirmReservation(ctx context.Context, reservationID uuid.UUID) error
-	CancelReservation(ctx context.Context, reservationID uuid.UUID) error
-	GetReservation(ctx context.Context, reservationID uuid.UUID) (*entities.Reservation, error)
+	ConfirmReservation(ctx context.Context, reservationID uuid.UUID, userID uuid.UUID, role string, paymentMethod string) error
+	CancelReservation(ctx context.Context, reservationID uuid.UUID, userID uuid.UUID, role string) error
+	GetReservation(ctx context.Context, reservationID uuid.UUID, userID uuid.UUID, role string) (*entities.Reservation, error)
+	ApplyPaymentResult(ctx context.Context, reservationID uuid.UUID, paid bool) error
+	RefundReservation(ctx context.Context, reservationID uuid.UUID, userID uuid.UUID, role string) (*dto.RefundResponse, error)
+	AdminRefundReservation(ctx context.Context, reservationID uuid.UUID, role string, amount int) (*dto.RefundResponse, error)
 	CleanupExpiredReservations(ctx context.Context) error
 	GetHistory(ctx context.Context, userID uuid.UUID) ([]*dto.ReservationHistory, error)
+	GetSeatAvailability(ctx context.Context, scheduleID uuid.UUID, userID uuid.UUID) (*dto.SeatAvailabilityResponse, error)
+}
+
+// Lama hold kursi sekaligus batas waktu pembayaran reservasi
+const reservationHoldDuration = 5 * time.Minute
+
+// PaymentProcessor adalah bagian dari payment module yang dipakai reservasi.
+// Didefinisikan di sini supaya payment module tidak perlu di-import langsung.
+type PaymentProcessor interface {
+	ChargeReservation(ctx context.Context, reservationID uuid.UUID, amount int, paymentMethod string) (*payment.Payment, error)
+	RefundReservation(ctx context.Context, reservationID uuid.UUID, amount int) (*payment.Payment, error)
 }
 
 type reservationService struct {
 	reservationRepo repository.ReservationRepository
 	seatRedisRepo   repository.SeatRedisRepository
+	pricing         PricingEngine
+	payments        PaymentProcessor
+	refunds         RefundPolicy
 }
 
-func NewReservationService(resRepo repository.ReservationRepository, redisRepo repository.SeatRedisRepository) ReservationService {
+func NewReservationService(resRepo repository.ReservationRepository, redisRepo repository.SeatRedisRepository, pricing PricingEngine, payments PaymentProcessor, refunds RefundPolicy) ReservationService {
 	return &reservationService{
 		reservationRepo: resRepo,
 		seatRedisRepo:   redisRepo,
+		pricing:         pricing,
+		payments:        payments,
+		refunds:         refunds,
 	}
 }
 
@@ -39,34 +62,107 @@ func (s *reservationService) CreateReservation(ctx context.Context, userID uuid.
 		return nil, errors.New("seats required")
 	}
 
-	if totalPrice <= 0 {
+	if totalPrice < 0 {
 		return nil, errors.New("invalid total price")
 	}
 
 	// Validate seat codes
-	for _, seat := range seats {
+	seen := make(map[string]bool, len(seats))
+	for i, seat := range seats {
+		seat = strings.ToUpper(strings.TrimSpace(seat))
 		if seat == "" {
 			return nil, errors.New("seat code cannot be empty")
 		}
+		if seen[seat] {
+			return nil, fmt.Errorf("%w: %s", customerrors.ErrDuplicateSeat, seat)
+		}
+		seen[seat] = true
+		seats[i] = seat
 	}
 
-	// Hold seats in Redis with 5 minute TTL
-	if err := s.seatRedisRepo.HoldSeats(ctx, scheduleID.String(), userID.String(), seats, 5*time.Minute); err != nil {
-		return nil, fmt.Errorf("failed to hold seats: %w", err)
+	// Akun yang belum verifikasi email masih bisa melihat film/jadwal, tapi belum bisa memesan
+	verified, err := s.reservationRepo.IsEmailVerified(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerrors.ErrDatabaseError, err)
+	}
+
+	if !verified {
+		return nil, fmt.Errorf("%w", customerrors.ErrEmailNotVerified)
+	}
+
+	schedule, err := s.reservationRepo.FindSchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerrors.ErrDatabaseError, err)
+	}
+
+	if schedule == nil {
+		return nil, fmt.Errorf("%w", customerrors.ErrScheduleNotFound)
+	}
+
+	if !time.Now().Before(schedule.StartTime) {
+		return nil, fmt.Errorf("%w", customerrors.ErrScheduleAlreadyStarted)
+	}
+
+	// Kursi harus ada di denah studio jadwal ini
+	studioSeats, err := s.reservationRepo.FindStudioSeats(ctx, schedule.StudioID, seats)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerrors.ErrDatabaseError, err)
+	}
+
+	seatByCode := make(map[string]studio.StudioSeat, len(studioSeats))
+	for _, seat := range studioSeats {
+		seatByCode[seat.Code] = seat
 	}
 
-	// Create reservation
+	// Harga selalu dihitung di server, total dari client hanya untuk verifikasi
+	pricingInputs := make([]SeatPricingInput, 0, len(seats))
+	for _, code := range seats {
+		seat, ok := seatByCode[code]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", customerrors.ErrSeatNotFound, code)
+		}
+		if seat.IsBlocked {
+			return nil, fmt.Errorf("%w: %s", customerrors.ErrSeatBlocked, code)
+		}
+		pricingInputs = append(pricingInputs, SeatPricingInput{SeatCode: code, Category: string(seat.Type)})
+	}
+
+	quote, err := s.pricing.Quote(schedule.Price, pricingInputs)
+	if err != nil {
+		return nil, err
+	}
+
+	if totalPrice > 0 && totalPrice != quote.Total {
+		return nil, fmt.Errorf("%w: expected %d, got %d", customerrors.ErrPriceMismatch, quote.Total, totalPrice)
+	}
+
+	// ID reservasi dibuat lebih dulu supaya hold di Redis tercatat atas nama reservasi ini
 	reservation := &entities.Reservation{
+		ID:         uuid.New(),
 		UserID:     userID,
 		ScheduleID: scheduleID,
-		TotalPrice: totalPrice,
+		TotalPrice: quote.Total,
 		Status:     entities.StatusPending,
-		ExpiresAt:  time.Now().Add(5 * time.Minute),
+		ExpiresAt:  time.Now().Add(reservationHoldDuration),
+	}
+
+	hold := holdFor(reservation)
+	if err := s.seatRedisRepo.HoldSeats(ctx, scheduleID.String(), hold, seats); err != nil {
+		return nil, fmt.Errorf("failed to hold seats: %w", err)
 	}
 
-	if err := s.reservationRepo.Create(ctx, reservation, seats); err != nil {
+	seatEntities := make([]entities.ReservationSeat, 0, len(quote.Items))
+	for _, item := range quote.Items {
+		seatEntities = append(seatEntities, entities.ReservationSeat{
+			SeatCode: item.SeatCode,
+			Category: item.Category,
+			Price:    item.Price,
+		})
+	}
+
+	if err := s.reservationRepo.Create(ctx, reservation, seatEntities); err != nil {
 		// Rollback: release seats in Redis
-		_ = s.seatRedisRepo.ReleaseSeats(ctx, scheduleID.String(), seats)
+		_ = s.seatRedisRepo.ReleaseSeats(ctx, scheduleID.String(), hold.ReservationID, seats)
 		return nil, fmt.Errorf("failed to create reservation: %w", err)
 	}
 
@@ -79,13 +175,17 @@ func (s *reservationService) CreateReservation(ctx context.Context, userID uuid.
 	return createdReservation, nil
 }
 
-func (s *reservationService) ConfirmReservation(ctx context.Context, reservationID uuid.UUID) error {
+func (s *reservationService) ConfirmReservation(ctx context.Context, reservationID uuid.UUID, userID uuid.UUID, role string, paymentMethod string) error {
 	// Get reservation
 	reservation, err := s.reservationRepo.FindByID(ctx, reservationID)
 	if err != nil {
 		return fmt.Errorf("reservation not found: %w", err)
 	}
 
+	if err := authorizeOwner(reservation, userID, role); err != nil {
+		return err
+	}
+
 	// Check if reservation can be confirmed
 	if !reservation.CanTransitionTo(entities.StatusPaid) {
 		return fmt.Errorf("cannot confirm reservation with status %s", reservation.Status)
@@ -96,16 +196,34 @@ func (s *reservationService) ConfirmReservation(ctx context.Context, reservation
 		return errors.New("reservation has expired")
 	}
 
+	// Tidak bisa membayar jadwal yang sudah mulai
+	if !time.Now().Before(reservation.Schedule.StartTime) {
+		return fmt.Errorf("%w", customerrors.ErrScheduleAlreadyStarted)
+	}
+
 	// Extract seat codes
 	seatCodes := extractSeatCodes(reservation)
 
-	// Update status in database
-	if err := s.reservationRepo.UpdateStatus(ctx, reservationID, entities.StatusPaid); err != nil {
+	// Payment aktif per reservasi dibatasi unique index, sehingga confirm yang bersamaan
+	// hanya satu yang men-charge. Reservasi tetap PENDING sampai dana berhasil di-capture.
+	if _, err := s.payments.ChargeReservation(ctx, reservationID, reservation.TotalPrice, paymentMethod); err != nil {
+		return fmt.Errorf("payment for reservation %s failed: %w", reservationID, err)
+	}
+
+	// Jika proses berhenti sebelum langkah ini, webhook charge.succeeded dari provider yang menandai PAID
+	if err := s.reservationRepo.UpdateStatus(ctx, reservationID, entities.StatusPending, entities.StatusPaid); err != nil {
+		// Reservasi sudah expired/dibatalkan selama pembayaran berjalan, dana dikembalikan
+		if _, refundErr := s.payments.RefundReservation(ctx, reservationID, reservation.TotalPrice); refundErr != nil {
+			fmt.Printf("Warning: reservation %s was charged but could not be confirmed or refunded: %v\n", reservationID, refundErr)
+		}
+		if errors.Is(err, customerrors.ErrInvalidStatusTransition) {
+			return fmt.Errorf("cannot confirm reservation: %w", err)
+		}
 		return fmt.Errorf("failed to update reservation status: %w", err)
 	}
 
 	// Move seats from temporary hold to confirmed in Redis
-	if err := s.seatRedisRepo.ConfirmSeats(ctx, reservation.ScheduleID.String(), seatCodes); err != nil {
+	if err := s.seatRedisRepo.ConfirmSeats(ctx, reservation.ScheduleID.String(), holdFor(reservation), seatCodes); err != nil {
 		// Log error but don't fail the operation as DB is already updated
 		// In production, you might want to implement compensation logic here
 		fmt.Printf("Warning: failed to confirm seats in Redis: %v\n", err)
@@ -114,13 +232,17 @@ func (s *reservationService) ConfirmReservation(ctx context.Context, reservation
 	return nil
 }
 
-func (s *reservationService) CancelReservation(ctx context.Context, reservationID uuid.UUID) error {
+func (s *reservationService) CancelReservation(ctx context.Context, reservationID uuid.UUID, userID uuid.UUID, role string) error {
 	// Get reservation
 	reservation, err := s.reservationRepo.FindByID(ctx, reservationID)
 	if err != nil {
 		return fmt.Errorf("reservation not found: %w", err)
 	}
 
+	if err := authorizeOwner(reservation, userID, role); err != nil {
+		return err
+	}
+
 	// Check if reservation can be canceled
 	if !reservation.CanTransitionTo(entities.StatusCanceled) {
 		return fmt.Errorf("cannot cancel reservation with status %s", reservation.Status)
@@ -130,12 +252,15 @@ func (s *reservationService) CancelReservation(ctx context.Context, reservationI
 	seatCodes := extractSeatCodes(reservation)
 
 	// Update status in database
-	if err := s.reservationRepo.UpdateStatus(ctx, reservationID, entities.StatusCanceled); err != nil {
+	if err := s.reservationRepo.UpdateStatus(ctx, reservationID, reservation.Status, entities.StatusCanceled); err != nil {
+		if errors.Is(err, customerrors.ErrInvalidStatusTransition) {
+			return fmt.Errorf("cannot cancel reservation: %w", err)
+		}
 		return fmt.Errorf("failed to update reservation status: %w", err)
 	}
 
 	// Release seats in Redis
-	if err := s.seatRedisRepo.ReleaseSeats(ctx, reservation.ScheduleID.String(), seatCodes); err != nil {
+	if err := s.seatRedisRepo.ReleaseSeats(ctx, reservation.ScheduleID.String(), reservation.ID.String(), seatCodes); err != nil {
 		// Log error but don't fail the operation as DB is already updated
 		fmt.Printf("Warning: failed to release seats in Redis: %v\n", err)
 	}
@@ -143,12 +268,55 @@ func (s *reservationService) CancelReservation(ctx context.Context, reservationI
 	return nil
 }
 
-func (s *reservationService) GetReservation(ctx context.Context, reservationID uuid.UUID) (*entities.Reservation, error) {
+// ApplyPaymentResult dipanggil dari webhook payment: sukses menjadi PAID, gagal menjadi CANCELED.
+// Event yang sama boleh datang berkali-kali, status yang sudah sesuai tidak diubah lagi.
+func (s *reservationService) ApplyPaymentResult(ctx context.Context, reservationID uuid.UUID, paid bool) error {
+	reservation, err := s.reservationRepo.FindByID(ctx, reservationID)
+	if err != nil {
+		return fmt.Errorf("reservation not found: %w", err)
+	}
+
+	target := entities.StatusCanceled
+	if paid {
+		target = entities.StatusPaid
+	}
+
+	if reservation.Status == target {
+		return nil
+	}
+
+	if !reservation.Status.IsValidTransition(target) {
+		return fmt.Errorf("%w: %s to %s", customerrors.ErrInvalidStatusTransition, reservation.Status, target)
+	}
+
+	if err := s.reservationRepo.UpdateStatus(ctx, reservationID, reservation.Status, target); err != nil {
+		return fmt.Errorf("failed to update reservation status: %w", err)
+	}
+
+	seatCodes := extractSeatCodes(reservation)
+	if paid {
+		err = s.seatRedisRepo.ConfirmSeats(ctx, reservation.ScheduleID.String(), holdFor(reservation), seatCodes)
+	} else {
+		err = s.seatRedisRepo.ReleaseSeats(ctx, reservation.ScheduleID.String(), reservation.ID.String(), seatCodes)
+	}
+	if err != nil {
+		// DB sudah ter-update, Redis cukup di-log
+		fmt.Printf("Warning: failed to sync seats in Redis for reservation %s: %v\n", reservationID, err)
+	}
+
+	return nil
+}
+
+func (s *reservationService) GetReservation(ctx context.Context, reservationID uuid.UUID, userID uuid.UUID, role string) (*entities.Reservation, error) {
 	reservation, err := s.reservationRepo.FindByID(ctx, reservationID)
 	if err != nil {
 		return nil, fmt.Errorf("reservation not found: %w", err)
 	}
 
+	if err := authorizeOwner(reservation, userID, role); err != nil {
+		return nil, err
+	}
+
 	return reservation, nil
 }
 
@@ -159,16 +327,34 @@ func (s *reservationService) CleanupExpiredReservations(ctx context.Context) err
 		return fmt.Errorf("failed to find expired reservations: %w", err)
 	}
 
-	// Update expired reservations status
-	if err := s.reservationRepo.UpdateExpiredReservations(ctx); err != nil {
+	if len(expiredReservations) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(expiredReservations))
+	for _, reservation := range expiredReservations {
+		ids = append(ids, reservation.ID)
+	}
+
+	// Update hanya reservasi yang ditemukan di atas; yang sempat dibayar di antaranya dilewati
+	expiredIDs, err := s.reservationRepo.UpdateExpiredReservations(ctx, ids)
+	if err != nil {
 		return fmt.Errorf("failed to update expired reservations: %w", err)
 	}
 
+	expired := make(map[uuid.UUID]bool, len(expiredIDs))
+	for _, id := range expiredIDs {
+		expired[id] = true
+	}
+
 	// Release seats in Redis for expired reservations
 	for _, reservation := range expiredReservations {
+		if !expired[reservation.ID] {
+			continue
+		}
 		seatCodes := extractSeatCodes(reservation)
 		if len(seatCodes) > 0 {
-			if err := s.seatRedisRepo.ReleaseSeats(ctx, reservation.ScheduleID.String(), seatCodes); err != nil {
+			if err := s.seatRedisRepo.ReleaseSeats(ctx, reservation.ScheduleID.String(), reservation.ID.String(), seatCodes); err != nil {
 				fmt.Printf("Warning: failed to release seats for expired reservation %s: %v\n", reservation.ID, err)
 			}
 		}
@@ -177,6 +363,116 @@ func (s *reservationService) CleanupExpiredReservations(ctx context.Context) err
 	return nil
 }
 
+// RefundReservation membatalkan reservasi yang sudah dibayar oleh pemiliknya,
+// nominal refund mengikuti RefundPolicy berdasarkan jarak ke jam tayang
+func (s *reservationService) RefundReservation(ctx context.Context, reservationID uuid.UUID, userID uuid.UUID, role string) (*dto.RefundResponse, error) {
+	reservation, err := s.reservationRepo.FindByID(ctx, reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("reservation not found: %w", err)
+	}
+
+	if err := authorizeOwner(reservation, userID, role); err != nil {
+		return nil, err
+	}
+
+	if reservation.Status != entities.StatusPaid {
+		return nil, fmt.Errorf("%w: reservation status is %s", customerrors.ErrRefundNotAllowed, reservation.Status)
+	}
+
+	amount, err := s.refunds.Amount(reservation.TotalPrice, reservation.Schedule.StartTime, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.refund(ctx, reservation, amount)
+}
+
+// AdminRefundReservation mengabaikan RefundPolicy; amount 0 berarti refund penuh
+func (s *reservationService) AdminRefundReservation(ctx context.Context, reservationID uuid.UUID, role string, amount int) (*dto.RefundResponse, error) {
+	if role != "admin" {
+		return nil, fmt.Errorf("%w", customerrors.ErrUnauthorizedUser)
+	}
+
+	reservation, err := s.reservationRepo.FindByID(ctx, reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("reservation not found: %w", err)
+	}
+
+	if reservation.Status != entities.StatusPaid {
+		return nil, fmt.Errorf("%w: reservation status is %s", customerrors.ErrRefundNotAllowed, reservation.Status)
+	}
+
+	if amount == 0 {
+		amount = reservation.TotalPrice
+	}
+
+	if amount < 0 || amount > reservation.TotalPrice {
+		return nil, fmt.Errorf("%w", customerrors.ErrInvalidRefundAmount)
+	}
+
+	return s.refund(ctx, reservation, amount)
+}
+
+// refund mengklaim perubahan status lalu mengembalikan dana lewat payment provider di dalam klaim tersebut,
+// sehingga refund yang bersamaan hanya berjalan sekali. Setelah itu kursi dilepas ke inventory.
+func (s *reservationService) refund(ctx context.Context, reservation *entities.Reservation, amount int) (*dto.RefundResponse, error) {
+	target := entities.StatusPartiallyRefunded
+	if amount >= reservation.TotalPrice {
+		target = entities.StatusRefunded
+	}
+
+	if !reservation.CanTransitionTo(target) {
+		return nil, fmt.Errorf("%w: %s to %s", customerrors.ErrInvalidStatusTransition, reservation.Status, target)
+	}
+
+	err := s.reservationRepo.UpdateStatusWith(ctx, reservation.ID, entities.StatusPaid, target, func() error {
+		if _, err := s.payments.RefundReservation(ctx, reservation.ID, amount); err != nil {
+			return fmt.Errorf("refund for reservation %s failed: %w", reservation.ID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, customerrors.ErrInvalidStatusTransition) {
+			return nil, fmt.Errorf("%w: %v", customerrors.ErrRefundNotAllowed, err)
+		}
+		return nil, err
+	}
+
+	seatCodes := extractSeatCodes(reservation)
+	if err := s.seatRedisRepo.ReleaseConfirmedSeats(ctx, reservation.ScheduleID.String(), reservation.ID.String(), seatCodes); err != nil {
+		fmt.Printf("Warning: failed to release refunded seats in Redis: %v\n", err)
+	}
+
+	return &dto.RefundResponse{
+		ReservationID: reservation.ID,
+		Status:        string(target),
+		TotalPrice:    reservation.TotalPrice,
+		RefundAmount:  amount,
+		RefundedAt:    time.Now(),
+	}, nil
+}
+
+// authorizeOwner memastikan reservasi hanya diakses pemiliknya, kecuali admin
+func authorizeOwner(reservation *entities.Reservation, userID uuid.UUID, role string) error {
+	if role == "admin" {
+		return nil
+	}
+
+	if userID == uuid.Nil || reservation.UserID != userID {
+		return fmt.Errorf("%w: reservation belongs to another user", customerrors.ErrForbidden)
+	}
+
+	return nil
+}
+
+func holdFor(reservation *entities.Reservation) repository.SeatHold {
+	return repository.SeatHold{
+		ReservationID: reservation.ID.String(),
+		UserID:        reservation.UserID.String(),
+		ExpiresAt:     reservation.ExpiresAt,
+	}
+}
+
 func extractSeatCodes(reservation *entities.Reservation) []string {
 	seats := make([]string, 0, len(reservation.Seats))
 	for _, seat := range reservation.Seats {
@@ -197,3 +493,120 @@ func (s *reservationService) GetHistory(ctx context.Context, userID uuid.UUID) (
 
 	return history, nil
 }
+
+// GetSeatAvailability menggabungkan denah studio, hold di Redis, dan reservasi di Postgres
+func (s *reservationService) GetSeatAvailability(ctx context.Context, scheduleID uuid.UUID, userID uuid.UUID) (*dto.SeatAvailabilityResponse, error) {
+	schedule, err := s.reservationRepo.FindSchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerrors.ErrDatabaseError, err)
+	}
+
+	if schedule == nil {
+		return nil, fmt.Errorf("%w", customerrors.ErrScheduleNotFound)
+	}
+
+	layout, err := s.reservationRepo.FindStudioLayout(ctx, schedule.StudioID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerrors.ErrDatabaseError, err)
+	}
+
+	held, err := s.seatRedisRepo.GetLockedSeats(ctx, scheduleID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get held seats: %w", err)
+	}
+
+	sold, err := s.seatRedisRepo.GetConfirmedSeats(ctx, scheduleID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get confirmed seats: %w", err)
+	}
+
+	booked, err := s.reservationRepo.FindBookedSeats(ctx, scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerrors.ErrDatabaseError, err)
+	}
+
+	// Postgres adalah sumber kebenaran untuk kursi terjual, Redis untuk hold
+	soldCodes := make(map[string]bool, len(sold)+len(booked))
+	for code := range sold {
+		soldCodes[code] = true
+	}
+	pendingOwner := make(map[string]string)
+	for _, seat := range booked {
+		if seat.Status == string(entities.StatusPaid) {
+			soldCodes[seat.SeatCode] = true
+		} else {
+			pendingOwner[seat.SeatCode] = seat.UserID.String()
+		}
+	}
+
+	pricingInputs := make([]SeatPricingInput, 0, len(layout))
+	for _, seat := range layout {
+		pricingInputs = append(pricingInputs, SeatPricingInput{SeatCode: seat.Code, Category: string(seat.Type)})
+	}
+
+	prices := make(map[string]int, len(layout))
+	if len(pricingInputs) > 0 {
+		quote, err := s.pricing.Quote(schedule.Price, pricingInputs)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range quote.Items {
+			prices[item.SeatCode] = item.Price
+		}
+	}
+
+	response := &dto.SeatAvailabilityResponse{
+		ScheduleID: scheduleID,
+		StudioID:   schedule.StudioID,
+		Summary: map[string]int{
+			dto.SeatStateAvailable: 0,
+			dto.SeatStateHeld:      0,
+			dto.SeatStateHeldByMe:  0,
+			dto.SeatStateSold:      0,
+			dto.SeatStateBlocked:   0,
+		},
+		Seats: make([]dto.SeatAvailability, 0, len(layout)),
+	}
+
+	me := userID.String()
+	for _, seat := range layout {
+		state := dto.SeatStateAvailable
+
+		owner, isHeld := pendingOwner[seat.Code]
+		if hold, ok := held[seat.Code]; ok {
+			owner, isHeld = hold.UserID, true
+		}
+
+		switch {
+		case seat.IsBlocked:
+			state = dto.SeatStateBlocked
+		case soldCodes[seat.Code]:
+			state = dto.SeatStateSold
+		case isHeld && owner == me:
+			state = dto.SeatStateHeldByMe
+		case isHeld:
+			state = dto.SeatStateHeld
+		}
+
+		if seat.RowIndex+1 > response.Rows {
+			response.Rows = seat.RowIndex + 1
+		}
+		if seat.ColumnIndex+1 > response.Columns {
+			response.Columns = seat.ColumnIndex + 1
+		}
+
+		response.Summary[state]++
+		response.Seats = append(response.Seats, dto.SeatAvailability{
+			Code:        seat.Code,
+			RowLabel:    seat.RowLabel,
+			Number:      seat.Number,
+			RowIndex:    seat.RowIndex,
+			ColumnIndex: seat.ColumnIndex,
+			Type:        string(seat.Type),
+			Price:       prices[seat.Code],
+			State:       state,
+		})
+	}
+
+	return response, nil
+}
@@ -1,19 +1,37 @@
 package router
 
 import (
+	"movie-ticket/infra/mailer"
 	"movie-ticket/internal/auth_module/handler"
 	"movie-ticket/internal/auth_module/repositories"
 	"movie-ticket/internal/auth_module/services"
+	"movie-ticket/internal/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
+// NewAuthService menyusun auth service beserta dependensinya, dipakai oleh router dan main
+func NewAuthService() services.AuthService {
+	return services.NewAuthSvc(repositories.NewAuthRepo(), repositories.NewPasswordResetRepo(), repositories.NewTwoFactorRepo(), repositories.NewLoginAttemptRepo(), mailer.NewFromEnv())
+}
+
 func InitAuthRoutes(r *gin.Engine) {
-	auth := repositories.NewAuthRepo()
-	authSvc := services.NewAuthSvc(auth)
+	authSvc := NewAuthService()
 
 	api := r.Group("api/v1")
 	{
 		handler.NewAuthHandler(api, authSvc)
 	}
+
+	apiSession := r.Group("/api/v1")
+	apiSession.Use(middleware.JwtMiddleware())
+	{
+		handler.NewAuthHandlerSession(apiSession, authSvc)
+	}
+
+	apiAdmin := r.Group("/api/v1/admin")
+	apiAdmin.Use(middleware.JwtMiddleware(), middleware.RequireRole("admin"), middleware.RequireAdminTwoFactor())
+	{
+		handler.NewAuthHandlerAdmin(apiAdmin, authSvc)
+	}
 }
@@ -8,4 +8,5 @@ func InitRouter(r *gin.Engine) {
 	InitStudioRouter(r)
 	InitialScheduleRouter(r)
 	InitReservationRouter(r)
+	InitPaymentRouter(r)
 }
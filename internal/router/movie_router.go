@@ -11,17 +11,25 @@ import (
 
 func InitMovieRoute(r *gin.Engine) {
 	movies := repositories.NewMovieRepo()
-	moviesSvc := services.NewMoviesService(movies)
+	genres := repositories.NewGenreRepo()
+	people := repositories.NewPersonRepo()
+	moviesSvc := services.NewMoviesService(movies, genres, people)
+	genreSvc := services.NewGenreService(genres)
+	personSvc := services.NewPersonService(people)
 
 	api := r.Group("/api/v1/")
 	api.Use(middleware.JwtMiddleware(), middleware.GinRoleChecker("admin", "user"))
 	{
 		handler.NewMoviehandlerUser(api, moviesSvc)
+		handler.NewGenreHandlerUser(api, genreSvc)
+		handler.NewPersonHandlerUser(api, personSvc)
 	}
 
 	apiAdmin := r.Group("/api/v1/admin")
-	api.Use(middleware.JwtMiddleware(), middleware.GinRoleChecker("admin"))
+	apiAdmin.Use(middleware.JwtMiddleware(), middleware.GinRoleChecker("admin"), middleware.RequireAdminTwoFactor())
 	{
 		handler.NewMovieHandlerAdmin(apiAdmin, moviesSvc)
+		handler.NewGenreHandlerAdmin(apiAdmin, genreSvc)
+		handler.NewPersonHandlerAdmin(apiAdmin, personSvc)
 	}
 }
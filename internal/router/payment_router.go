@@ -0,0 +1,55 @@
+package router
+
+import (
+	"log"
+	"movie-ticket/config"
+	"movie-ticket/infra/postgres"
+	"movie-ticket/internal/payment_module/handler"
+	"movie-ticket/internal/payment_module/provider"
+	repository "movie-ticket/internal/payment_module/repositories"
+	service "movie-ticket/internal/payment_module/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newPaymentProviders menyusun provider yang tersedia. PAYMENT_PROVIDER wajib diisi dan harus terdaftar.
+// Provider fake hanya didaftarkan jika PAYMENT_FAKE_ENABLED=true (dev/test), supaya deploy yang
+// kurang env tidak diam-diam memakai gateway palsu.
+func newPaymentProviders() *provider.Registry {
+	var providers []provider.Provider
+
+	if config.Get("PAYMENT_FAKE_ENABLED") == "true" {
+		secret := config.Get("FAKE_PAYMENT_WEBHOOK_SECRET")
+		if secret == "" {
+			log.Fatal("FAKE_PAYMENT_WEBHOOK_SECRET wajib diisi jika PAYMENT_FAKE_ENABLED=true")
+		}
+		providers = append(providers, provider.NewFakeProvider(secret))
+	}
+
+	registry := provider.NewRegistry(config.Get("PAYMENT_PROVIDER"), providers...)
+	if _, err := registry.Default(); err != nil {
+		log.Fatalf("PAYMENT_PROVIDER tidak valid: %v", err)
+	}
+
+	return registry
+}
+
+// NewPaymentService menyusun payment service yang dipakai reservasi untuk charge
+func NewPaymentService() service.PaymentService {
+	return service.NewPaymentService(repository.NewPaymentRepository(postgres.DB), newPaymentProviders())
+}
+
+func InitPaymentRouter(c *gin.Engine) {
+	webhookSvc := service.NewWebhookService(
+		repository.NewPaymentRepository(postgres.DB),
+		repository.NewPaymentEventRepository(postgres.DB),
+		newPaymentProviders(),
+		NewReservationService(),
+	)
+
+	// Webhook dipanggil oleh provider, autentikasinya lewat signature bukan JWT
+	api := c.Group("/api/v1")
+	{
+		handler.NewPaymentHandler(api, webhookSvc)
+	}
+}
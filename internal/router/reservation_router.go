@@ -11,14 +11,26 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func InitReservationRouter(c *gin.Engine) {
+// NewReservationService menyusun reservation service beserta dependensinya,
+// dipakai oleh router dan worker expiry
+func NewReservationService() service.ReservationService {
 	repoDB := repository.NewReservationRepository(postgres.DB)
 	repoRedis := repository.NewSeatRedisRepository(redis_config.RedisClient)
-	svc := service.NewReservationService(repoDB, repoRedis)
+	return service.NewReservationService(repoDB, repoRedis, service.NewPricingEngine(), NewPaymentService(), service.NewRefundPolicy())
+}
+
+func InitReservationRouter(c *gin.Engine) {
+	svc := NewReservationService()
 
 	api := c.Group("/api/v1")
 	api.Use(middleware.JwtMiddleware(), middleware.RequireRole("user", "admin"))
 	{
 		handler.NewReservationHandler(api, svc)
 	}
+
+	apiAdmin := c.Group("/api/v1/admin")
+	apiAdmin.Use(middleware.JwtMiddleware(), middleware.RequireRole("admin"), middleware.RequireAdminTwoFactor())
+	{
+		handler.NewReservationHandlerAdmin(apiAdmin, svc)
+	}
 }
@@ -14,7 +14,7 @@ func InitialScheduleRouter(c *gin.Engine) {
 	svc := services.NewShceduleSvc(r)
 
 	apiAdmin := c.Group("/api/v1/admin")
-	apiAdmin.Use(middleware.JwtMiddleware(), middleware.RequireRole("admin"))
+	apiAdmin.Use(middleware.JwtMiddleware(), middleware.RequireRole("admin"), middleware.RequireAdminTwoFactor())
 	{
 		handler.NewScheduleHandlerAdmin(apiAdmin, &svc)
 	}
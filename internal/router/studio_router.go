@@ -12,16 +12,19 @@ import (
 func InitStudioRouter(r *gin.Engine) {
 	studioRepo := repositories.NewStudioRepo()
 	studioSvc := services.NewStudioService(studioRepo)
+	seatSvc := services.NewStudioSeatService(repositories.NewStudioSeatRepo(), studioRepo)
 
 	api := r.Group("/api/v1")
 	api.Use(middleware.JwtMiddleware(), middleware.RequireRole("admin", "user"))
 	{
 		handlers.NewStudioHandlerUser(api, &studioSvc)
+		handlers.NewStudioSeatHandlerUser(api, seatSvc)
 	}
 
 	apiAdmin := r.Group("/api/v1/admin")
-	api.Use(middleware.JwtMiddleware(), middleware.RequireRole("admin"))
+	apiAdmin.Use(middleware.JwtMiddleware(), middleware.RequireRole("admin"), middleware.RequireAdminTwoFactor())
 	{
 		handlers.NewStudioHandlerAdmin(apiAdmin, &studioSvc)
+		handlers.NewStudioSeatHandlerAdmin(apiAdmin, seatSvc)
 	}
 }
@@ -10,7 +10,9 @@ var (
 	ErrInvalidScheduleId = errors.New("invalid schedule id format")
 	ErrUnauthorizedUser  = errors.New("forbidden user")
 	ErrTimeStart         = errors.New("the start time must not be earlier than the end time")
-	ErrInactiveMovie     = errors.New("unable to create a schedule because the movie is inactive")
+	ErrOutsideRunWindow  = errors.New("the showtime is outside the movie run window")
 	ErrScheduleConflict  = errors.New("do not schedule studio sessions that conflict with each other.")
 	ErrPriceInput        = errors.New("the price of the ticket must not be zero.")
+	ErrInvalidShowtime   = errors.New("invalid showtime format, use RFC3339 or YYYY-MM-DD HH:MM")
+	ErrInvalidFilter     = errors.New("invalid schedule filter")
 )
@@ -0,0 +1,81 @@
+package customtype
+
+import (
+	customerrors "movie-ticket/internal/schedule_module/custom_errors"
+	"strings"
+	"time"
+)
+
+// Format yang diterima untuk tanggal + jam tayang
+var dateTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+}
+
+// Format jam saja, hanya untuk end_time (tanggal mengikuti start_time)
+var clockLayouts = []string{
+	"15:04:05",
+	"15:04",
+}
+
+const DateLayout = "2006-01-02"
+
+// ParseShowtime mem-parse tanggal + jam tayang. Input tanpa offset
+// dianggap berada di timezone loc.
+func ParseShowtime(value string, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range dateTimeLayouts {
+		var (
+			t   time.Time
+			err error
+		)
+		if layout == time.RFC3339 {
+			t, err = time.Parse(layout, value)
+		} else {
+			t, err = time.ParseInLocation(layout, value, loc)
+		}
+		if err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, customerrors.ErrInvalidShowtime
+}
+
+// ParseEndTime mem-parse waktu selesai. Selain format lengkap, end_time boleh
+// berupa jam saja (HH:MM); tanggalnya mengikuti start dan otomatis maju
+// satu hari jika jamnya tidak lebih dari jam mulai (tayangan lewat tengah malam).
+func ParseEndTime(value string, start time.Time, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := ParseShowtime(value, loc); err == nil {
+		return t, nil
+	}
+
+	startLocal := start.In(loc)
+	for _, layout := range clockLayouts {
+		clock, err := time.Parse(layout, value)
+		if err != nil {
+			continue
+		}
+
+		end := time.Date(startLocal.Year(), startLocal.Month(), startLocal.Day(),
+			clock.Hour(), clock.Minute(), clock.Second(), 0, loc)
+		if !end.After(startLocal) {
+			end = end.AddDate(0, 0, 1)
+		}
+		return end, nil
+	}
+
+	return time.Time{}, customerrors.ErrInvalidShowtime
+}
+
+// DayRange mengembalikan rentang [00:00, 00:00 hari berikutnya) untuk tanggal YYYY-MM-DD
+func DayRange(date string, loc *time.Location) (time.Time, time.Time, error) {
+	day, err := time.ParseInLocation(DateLayout, strings.TrimSpace(date), loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return day, day.AddDate(0, 0, 1), nil
+}
@@ -0,0 +1,121 @@
+package customtype
+
+import (
+	"errors"
+	customerrors "movie-ticket/internal/schedule_module/custom_errors"
+	"testing"
+	"time"
+)
+
+var jakarta = time.FixedZone("WIB", 7*60*60)
+
+func TestParseShowtime(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"RFC3339 dengan offset", "2026-03-10T19:30:00+07:00", time.Date(2026, 3, 10, 19, 30, 0, 0, jakarta), false},
+		{"RFC3339 UTC", "2026-03-10T12:30:00Z", time.Date(2026, 3, 10, 19, 30, 0, 0, jakarta), false},
+		{"tanpa offset memakai loc", "2026-03-10T19:30:00", time.Date(2026, 3, 10, 19, 30, 0, 0, jakarta), false},
+		{"spasi dengan detik", "2026-03-10 19:30:05", time.Date(2026, 3, 10, 19, 30, 5, 0, jakarta), false},
+		{"tanpa detik", "2026-03-10T19:30", time.Date(2026, 3, 10, 19, 30, 0, 0, jakarta), false},
+		{"spasi tanpa detik", " 2026-03-10 19:30 ", time.Date(2026, 3, 10, 19, 30, 0, 0, jakarta), false},
+		{"tanggal saja", "2026-03-10", time.Time{}, true},
+		{"jam saja", "19:30", time.Time{}, true},
+		{"format lain", "10/03/2026 19:30", time.Time{}, true},
+		{"kosong", "", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseShowtime(tt.value, jakarta)
+			if tt.wantErr {
+				if !errors.Is(err, customerrors.ErrInvalidShowtime) {
+					t.Fatalf("ParseShowtime(%q) error = %v, want ErrInvalidShowtime", tt.value, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseShowtime(%q) unexpected error: %v", tt.value, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseShowtime(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEndTime(t *testing.T) {
+	start := time.Date(2026, 3, 10, 21, 0, 0, 0, jakarta)
+
+	tests := []struct {
+		name    string
+		value   string
+		start   time.Time
+		want    time.Time
+		wantErr bool
+	}{
+		{"jam di hari yang sama", "23:15", start, time.Date(2026, 3, 10, 23, 15, 0, 0, jakarta), false},
+		{"jam dengan detik", "23:15:30", start, time.Date(2026, 3, 10, 23, 15, 30, 0, jakarta), false},
+		{"lewat tengah malam", "00:45", start, time.Date(2026, 3, 11, 0, 45, 0, 0, jakarta), false},
+		{"sama dengan jam mulai maju sehari", "21:00", start, time.Date(2026, 3, 11, 21, 0, 0, 0, jakarta), false},
+		// start disimpan UTC, tanggal tetap mengikuti timezone bioskop
+		{"start dalam UTC", "00:45", start.UTC(), time.Date(2026, 3, 11, 0, 45, 0, 0, jakarta), false},
+		{"format lengkap", "2026-03-11T01:00", start, time.Date(2026, 3, 11, 1, 0, 0, 0, jakarta), false},
+		{"format tidak dikenal", "jam sembilan", start, time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEndTime(tt.value, tt.start, jakarta)
+			if tt.wantErr {
+				if !errors.Is(err, customerrors.ErrInvalidShowtime) {
+					t.Fatalf("ParseEndTime(%q) error = %v, want ErrInvalidShowtime", tt.value, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseEndTime(%q) unexpected error: %v", tt.value, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseEndTime(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDayRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		date    string
+		from    time.Time
+		to      time.Time
+		wantErr bool
+	}{
+		{"tanggal biasa", "2026-03-10", time.Date(2026, 3, 10, 0, 0, 0, 0, jakarta), time.Date(2026, 3, 11, 0, 0, 0, 0, jakarta), false},
+		{"akhir bulan", " 2026-02-28 ", time.Date(2026, 2, 28, 0, 0, 0, 0, jakarta), time.Date(2026, 3, 1, 0, 0, 0, 0, jakarta), false},
+		{"akhir tahun", "2026-12-31", time.Date(2026, 12, 31, 0, 0, 0, 0, jakarta), time.Date(2027, 1, 1, 0, 0, 0, 0, jakarta), false},
+		{"tanggal tidak valid", "2026-02-30", time.Time{}, time.Time{}, true},
+		{"bukan tanggal", "besok", time.Time{}, time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := DayRange(tt.date, jakarta)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("DayRange(%q) expected error", tt.date)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DayRange(%q) unexpected error: %v", tt.date, err)
+			}
+			if !from.Equal(tt.from) || !to.Equal(tt.to) {
+				t.Errorf("DayRange(%q) = [%s, %s), want [%s, %s)", tt.date, from, to, tt.from, tt.to)
+			}
+		})
+	}
+}
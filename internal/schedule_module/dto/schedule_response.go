@@ -17,8 +17,8 @@ type ScheduleResponse struct {
 	StudioId       uuid.UUID `json:"studio_id"`
 	StudioName     string    `json:"studio_name"`
 	StudioLocation string    `json:"studio_location"`
-	StartTime      string    `json:"start_time"`
-	EndTime        string    `json:"end_time"`
+	StartTime      time.Time `json:"start_time"`
+	EndTime        time.Time `json:"end_time"`
 	Price          int       `json:"price"`
 	CreatedAt      time.Time `json:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at"`
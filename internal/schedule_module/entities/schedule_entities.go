@@ -12,8 +12,8 @@ type Schedules struct {
 	ID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
 	MovieID   uuid.UUID `gorm:"type:uuid;not null" json:"movie_id"`
 	StudioID  uuid.UUID `gorm:"type:uuid;not null" json:"studio_id"`
-	StartTime string    `gorm:"type:time;not null" json:"start_time"`
-	EndTime   string    `gorm:"type:time;not null" json:"end_time"`
+	StartTime time.Time `gorm:"type:timestamptz;not null" json:"start_time"`
+	EndTime   time.Time `gorm:"type:timestamptz;not null" json:"end_time"`
 	Price     int       `gorm:"not null" json:"price"`
 	CreatedAt time.Time `gorm:"autoCreateTime;" json:"created_at"`
 	UpdatedAt time.Time `gorm:"autoCreateTime;autoUpdateTime" json:"updated_at"`
@@ -2,14 +2,18 @@ package handler
 
 import (
 	"errors"
+	"fmt"
+	"movie-ticket/config"
 	"movie-ticket/internal/middleware"
 	movieError "movie-ticket/internal/movie_module/custom_error"
 	customerrors "movie-ticket/internal/schedule_module/custom_errors"
+	customtype "movie-ticket/internal/schedule_module/custom_type"
 	"movie-ticket/internal/schedule_module/dto"
 	"movie-ticket/internal/schedule_module/services"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type ScheduleHandler struct {
@@ -31,14 +35,14 @@ func NewScheduleHandlerUser(r *gin.RouterGroup, svc *services.ScheduleServices)
 
 // CreateSchedule godoc
 // @Summary Membuat jadwal tayang baru (Admin only)
-// @Description Membuat jadwal tayang baru untuk movie tertentu dengan studio, waktu, dan harga yang ditentukan. Hanya admin yang dapat mengakses endpoint ini
+// @Description Membuat jadwal tayang baru untuk movie tertentu dengan studio, waktu, dan harga yang ditentukan. start_time berisi tanggal + jam (RFC3339 atau YYYY-MM-DD HH:MM di timezone bioskop); end_time boleh jam saja dan otomatis lewat tengah malam, atau dikosongkan untuk mengikuti durasi film. Hanya admin yang dapat mengakses endpoint ini
 // @Tags Schedules
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer token" default(Bearer <token>)
 // @Param request body dto.ScheduleCreateRequest true "Schedule creation data"
 // @Success 201 {object} map[string]interface{} "Schedule created successfully"
-// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid input, waktu mulai, movie tidak aktif, atau harga invalid"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid input, waktu mulai di luar masa tayang movie, atau harga invalid"
 // @Failure 401 {object} map[string]interface{} "Unauthorized - User bukan admin"
 // @Failure 404 {object} map[string]interface{} "Not Found - Movie tidak ditemukan"
 // @Failure 409 {object} map[string]interface{} "Conflict - Jadwal bertabrakan dengan jadwal lain"
@@ -66,11 +70,12 @@ func (h *ScheduleHandler) CreateSchedule(c *gin.Context) {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		case errors.Is(err, customerrors.ErrInvalidInput):
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		case errors.Is(err, customerrors.ErrTimeStart):
+		case errors.Is(err, customerrors.ErrTimeStart),
+			errors.Is(err, customerrors.ErrInvalidShowtime):
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		case errors.Is(err, movieError.ErrMovieNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		case errors.Is(err, customerrors.ErrInactiveMovie):
+		case errors.Is(err, customerrors.ErrOutsideRunWindow):
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		case errors.Is(err, customerrors.ErrScheduleConflict):
 			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
@@ -87,22 +92,36 @@ func (h *ScheduleHandler) CreateSchedule(c *gin.Context) {
 
 // Get godoc
 // @Summary Mendapatkan daftar semua jadwal tayang
-// @Description Mengambil semua jadwal tayang yang tersedia untuk semua movie
+// @Description Mengambil jadwal tayang, bisa difilter berdasarkan tanggal, rentang waktu, movie, dan studio
 // @Tags Schedules
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param date query string false "Tanggal tayang (YYYY-MM-DD, timezone bioskop)"
+// @Param from query string false "Jadwal mulai dari (RFC3339 atau YYYY-MM-DD HH:MM)"
+// @Param to query string false "Jadwal mulai sebelum (RFC3339 atau YYYY-MM-DD HH:MM)"
+// @Param movie_id query string false "Movie ID" format(uuid)
+// @Param studio_id query string false "Studio ID" format(uuid)
 // @Success 200 {object} dto.MessageResponse "Data jadwal berhasil diambil"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Filter tidak valid"
 // @Failure 404 {object} map[string]interface{} "Not Found - Jadwal tidak ditemukan"
 // @Failure 500 {object} map[string]interface{} "Internal Server Error"
 // @Router /schedule [get]
 // @Security BearerAuth
 func (h *ScheduleHandler) Get(c *gin.Context) {
-	schedules, err := h.svc.Get()
+	filter, err := parseScheduleFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	schedules, err := h.svc.Get(filter)
 	if err != nil {
 		switch {
 		case errors.Is(err, customerrors.ErrScheduleNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, customerrors.ErrInvalidFilter):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
@@ -159,7 +178,7 @@ func (h *ScheduleHandler) GetById(c *gin.Context) {
 // @Param id path string true "Schedule ID" format(uuid)
 // @Param request body dto.ScheduleUpdateRequest true "Schedule update data"
 // @Success 200 {object} dto.MessageResponse "Schedule updated successfully"
-// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid input, schedule ID, waktu mulai, atau harga"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid input, schedule ID, waktu mulai di luar masa tayang movie, atau harga"
 // @Failure 401 {object} map[string]interface{} "Unauthorized - User bukan admin"
 // @Failure 404 {object} map[string]interface{} "Not Found - Jadwal tidak ditemukan"
 // @Failure 409 {object} map[string]interface{} "Conflict - Jadwal bertabrakan dengan jadwal lain"
@@ -188,9 +207,13 @@ func (h *ScheduleHandler) UpdateSchedule(c *gin.Context) {
 		case errors.Is(err, customerrors.ErrInvalidScheduleId),
 			errors.Is(err, customerrors.ErrInvalidInput):
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		case errors.Is(err, customerrors.ErrScheduleNotFound):
+		case errors.Is(err, customerrors.ErrScheduleNotFound),
+			errors.Is(err, movieError.ErrMovieNotFound):
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		case errors.Is(err, customerrors.ErrTimeStart):
+		case errors.Is(err, customerrors.ErrOutsideRunWindow):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, customerrors.ErrTimeStart),
+			errors.Is(err, customerrors.ErrInvalidShowtime):
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		case errors.Is(err, customerrors.ErrScheduleConflict):
 			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
@@ -249,3 +272,51 @@ func (h *ScheduleHandler) DeleteSchedule(c *gin.Context) {
 		Message: "Successfully deleted schedule",
 	})
 }
+
+// parseScheduleFilter membaca query filter GET /schedule
+func parseScheduleFilter(c *gin.Context) (dto.ScheduleFilter, error) {
+	var filter dto.ScheduleFilter
+	loc := config.Location()
+
+	if date := c.Query("date"); date != "" {
+		from, to, err := customtype.DayRange(date, loc)
+		if err != nil {
+			return filter, fmt.Errorf("%w: date must be YYYY-MM-DD", customerrors.ErrInvalidFilter)
+		}
+		filter.From, filter.To = &from, &to
+	}
+
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err := customtype.ParseShowtime(fromStr, loc)
+		if err != nil {
+			return filter, fmt.Errorf("%w: from", customerrors.ErrInvalidFilter)
+		}
+		filter.From = &from
+	}
+
+	if toStr := c.Query("to"); toStr != "" {
+		to, err := customtype.ParseShowtime(toStr, loc)
+		if err != nil {
+			return filter, fmt.Errorf("%w: to", customerrors.ErrInvalidFilter)
+		}
+		filter.To = &to
+	}
+
+	if movieID := c.Query("movie_id"); movieID != "" {
+		id, err := uuid.Parse(movieID)
+		if err != nil {
+			return filter, fmt.Errorf("%w: movie_id", customerrors.ErrInvalidFilter)
+		}
+		filter.MovieID = &id
+	}
+
+	if studioID := c.Query("studio_id"); studioID != "" {
+		id, err := uuid.Parse(studioID)
+		if err != nil {
+			return filter, fmt.Errorf("%w: studio_id", customerrors.ErrInvalidFilter)
+		}
+		filter.StudioID = &id
+	}
+
+	return filter, nil
+}
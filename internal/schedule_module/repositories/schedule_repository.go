@@ -3,18 +3,21 @@ package repositories
 import (
 	"fmt"
 	"movie-ticket/infra/postgres"
+	"movie-ticket/internal/schedule_module/dto"
 	"movie-ticket/internal/schedule_module/entities"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type ScheduleRepository interface {
 	Create(req *entities.Schedules) error
-	Get() ([]entities.Schedules, error)
+	Get(filter dto.ScheduleFilter) ([]entities.Schedules, error)
 	GetById(id uuid.UUID) (*entities.Schedules, error)
 	Update(id uuid.UUID, req *entities.Schedules) error
 	Delete(id uuid.UUID) error
 	GetSchedulesByStudioID(studioID uuid.UUID) ([]*entities.Schedules, error)
+	FindOverlapping(studioID uuid.UUID, start, end time.Time, excludeID uuid.UUID) ([]*entities.Schedules, error)
 }
 
 type scheduleRepo struct{}
@@ -31,10 +34,25 @@ func (repo *scheduleRepo) Create(req *entities.Schedules) error {
 	return nil
 }
 
-func (repo *scheduleRepo) Get() ([]entities.Schedules, error) {
+func (repo *scheduleRepo) Get(filter dto.ScheduleFilter) ([]entities.Schedules, error) {
 	var schedules []entities.Schedules
 
-	err := postgres.DB.Preload("Movie").Preload("Studio").Find(&schedules).Error
+	query := postgres.DB.Preload("Movie").Preload("Studio")
+
+	if filter.MovieID != nil {
+		query = query.Where("movie_id = ?", *filter.MovieID)
+	}
+	if filter.StudioID != nil {
+		query = query.Where("studio_id = ?", *filter.StudioID)
+	}
+	if filter.From != nil {
+		query = query.Where("start_time >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("start_time < ?", *filter.To)
+	}
+
+	err := query.Order("start_time ASC").Find(&schedules).Error
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to get schedule: %w", err)
@@ -52,6 +70,10 @@ func (repo *scheduleRepo) GetById(id uuid.UUID) (*entities.Schedules, error) {
 		return nil, fmt.Errorf("failed to get schedule: %w", err)
 	}
 
+	if schedule.ID == uuid.Nil {
+		return nil, nil
+	}
+
 	return &schedule, nil
 }
 
@@ -100,3 +122,18 @@ func (repo *scheduleRepo) GetSchedulesByStudioID(studioID uuid.UUID) ([]*entitie
 
 	return schedulesByStudioId, nil
 }
+
+// FindOverlapping mencari jadwal di studio yang sama yang beririsan dengan rentang [start, end)
+func (repo *scheduleRepo) FindOverlapping(studioID uuid.UUID, start, end time.Time, excludeID uuid.UUID) ([]*entities.Schedules, error) {
+	var schedules []*entities.Schedules
+
+	err := postgres.DB.Where("studio_id = ? AND id <> ? AND start_time < ? AND end_time > ?", studioID, excludeID, end, start).
+		Order("start_time ASC").
+		Find(&schedules).Error
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule: %w", err)
+	}
+
+	return schedules, nil
+}
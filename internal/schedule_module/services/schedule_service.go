@@ -2,9 +2,12 @@ package services
 
 import (
 	"fmt"
+	"movie-ticket/config"
 	movieError "movie-ticket/internal/movie_module/custom_error"
+	movieEntity "movie-ticket/internal/movie_module/entities"
 	movie "movie-ticket/internal/movie_module/repositories"
 	customerror "movie-ticket/internal/schedule_module/custom_errors"
+	customtype "movie-ticket/internal/schedule_module/custom_type"
 	"movie-ticket/internal/schedule_module/dto"
 	"movie-ticket/internal/schedule_module/entities"
 	"movie-ticket/internal/schedule_module/repositories"
@@ -17,7 +20,7 @@ import (
 
 type ScheduleServices interface {
 	Create(role string, req *dto.ScheduleCreateRequest) (*dto.ScheduleResponse, error)
-	Get() ([]*dto.ScheduleResponse, error)
+	Get(filter dto.ScheduleFilter) ([]*dto.ScheduleResponse, error)
 	GetById(id string) (*dto.ScheduleResponse, error)
 	Update(role, id string, req *dto.ScheduleUpdateRequest) (*dto.ScheduleResponse, error)
 	Delete(role, id string) error
@@ -46,20 +49,11 @@ func (svc *svcSchedule) Create(role string, req *dto.ScheduleCreateRequest) (*dt
 		return nil, fmt.Errorf("%w", customerror.ErrInvalidInput)
 	}
 
-	layout := "15:04:05" // format jam:menit:detik
+	loc := config.Location()
 
-	start, err := time.Parse(layout, req.StartTime)
+	start, err := customtype.ParseShowtime(req.StartTime, loc)
 	if err != nil {
-		return nil, fmt.Errorf("invalid start time format")
-	}
-
-	end, err := time.Parse(layout, req.EndTime)
-	if err != nil {
-		return nil, fmt.Errorf("invalid end time format")
-	}
-
-	if !start.Before(end) {
-		return nil, fmt.Errorf("%w", customerror.ErrTimeStart)
+		return nil, fmt.Errorf("%w: start_time", customerror.ErrInvalidShowtime)
 	}
 
 	checkMovie, err := svc.movieRepo.GetMovieById(req.MovieID)
@@ -71,44 +65,37 @@ func (svc *svcSchedule) Create(role string, req *dto.ScheduleCreateRequest) (*dt
 		return nil, fmt.Errorf("%w", movieError.ErrMovieNotFound)
 	}
 
-	if !checkMovie.Status {
-		return nil, fmt.Errorf("%w", customerror.ErrInactiveMovie)
+	if err := checkRunWindow(checkMovie, start, loc); err != nil {
+		return nil, err
 	}
 
-	if req.Price <= 0 {
-		return nil, fmt.Errorf("%w", customerror.ErrPriceInput)
+	// end_time opsional, default mengikuti durasi film
+	end := start.Add(time.Duration(checkMovie.Duration_Minutes) * time.Minute)
+	if strings.TrimSpace(req.EndTime) != "" {
+		end, err = customtype.ParseEndTime(req.EndTime, start, loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: end_time", customerror.ErrInvalidShowtime)
+		}
 	}
 
-	existingSchedules, err := svc.repo.GetSchedulesByStudioID(req.StudioID)
-	if err != nil {
-		return nil, fmt.Errorf("%w: %v", movieError.ErrDatabaseError, err)
+	if !start.Before(end) {
+		return nil, fmt.Errorf("%w", customerror.ErrTimeStart)
 	}
 
-	if existingSchedules != nil && len(existingSchedules) > 0 {
-		for _, s := range existingSchedules {
-			existingStart, err := time.Parse(layout, s.StartTime)
-			if err != nil {
-				return nil, fmt.Errorf("invalid existing start time format: %v", err)
-			}
-
-			existingEnd, err := time.Parse(layout, s.EndTime)
-			if err != nil {
-				return nil, fmt.Errorf("invalid existing end time format: %v", err)
-			}
+	if req.Price <= 0 {
+		return nil, fmt.Errorf("%w", customerror.ErrPriceInput)
+	}
 
-			if (start.Before(existingEnd) && end.After(existingStart)) ||
-				(start.Equal(existingStart) || end.Equal(existingEnd)) {
-				return nil, fmt.Errorf("%w", customerror.ErrScheduleConflict)
-			}
-		}
+	if err := svc.checkConflict(req.StudioID, start, end, uuid.Nil); err != nil {
+		return nil, err
 	}
 
 	schedule := &entities.Schedules{
 		ID:        uuid.New(),
 		MovieID:   req.MovieID,
 		StudioID:  req.StudioID,
-		StartTime: start.Format(layout),
-		EndTime:   end.Format(layout),
+		StartTime: start,
+		EndTime:   end,
 		Price:     req.Price,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
@@ -121,8 +108,12 @@ func (svc *svcSchedule) Create(role string, req *dto.ScheduleCreateRequest) (*dt
 	return svc.toScheduleResponse(schedule), nil
 }
 
-func (svc *svcSchedule) Get() ([]*dto.ScheduleResponse, error) {
-	schedules, err := svc.repo.Get()
+func (svc *svcSchedule) Get(filter dto.ScheduleFilter) ([]*dto.ScheduleResponse, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, fmt.Errorf("%w: from must be before to", customerror.ErrInvalidFilter)
+	}
+
+	schedules, err := svc.repo.Get(filter)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err.Error())
 	}
@@ -165,7 +156,7 @@ func (svc *svcSchedule) Update(role, id string, req *dto.ScheduleUpdateRequest)
 		return nil, fmt.Errorf("%w", customerror.ErrUnauthorizedUser)
 	}
 
-	if id == "" {
+	if id == "" || req == nil {
 		return nil, fmt.Errorf("%w", customerror.ErrInvalidInput)
 	}
 
@@ -174,26 +165,6 @@ func (svc *svcSchedule) Update(role, id string, req *dto.ScheduleUpdateRequest)
 		return nil, fmt.Errorf("%w", customerror.ErrInvalidScheduleId)
 	}
 
-	layout := "15:04:05" // format jam:menit:detik
-
-	start, err := time.Parse(layout, *req.StartTime)
-	if err != nil {
-		return nil, fmt.Errorf("invalid start time format")
-	}
-
-	end, err := time.Parse(layout, *req.EndTime)
-	if err != nil {
-		return nil, fmt.Errorf("invalid end time format")
-	}
-
-	if !start.Before(end) {
-		return nil, fmt.Errorf("%w", customerror.ErrTimeStart)
-	}
-
-	if start.After(end) || start.Equal(end) {
-		return nil, fmt.Errorf("%w", customerror.ErrTimeStart)
-	}
-
 	existingSchedule, err := svc.repo.GetById(idParse)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
@@ -203,34 +174,39 @@ func (svc *svcSchedule) Update(role, id string, req *dto.ScheduleUpdateRequest)
 		return nil, fmt.Errorf("%w", customerror.ErrScheduleNotFound)
 	}
 
-	existingShedules, err := svc.repo.GetSchedulesByStudioID(*req.StudioID)
-	if err != nil {
-		return nil, fmt.Errorf("%w: %v", movieError.ErrDatabaseError, err)
+	if req.Price != nil && *req.Price <= 0 {
+		return nil, fmt.Errorf("%w", customerror.ErrPriceInput)
 	}
 
-	if existingShedules == nil {
-		return nil, fmt.Errorf("%w", movieError.ErrMovieNotFound)
+	scheduleUpdate := *existingSchedule
+	if err := svc.applyUpdates(&scheduleUpdate, req); err != nil {
+		return nil, err
 	}
 
-	for _, s := range existingShedules {
-		if s.ID == idParse {
-			continue
+	// Masa tayang dicek ulang jika movie atau tanggal tayang berubah
+	if scheduleUpdate.MovieID != existingSchedule.MovieID || !scheduleUpdate.StartTime.Equal(existingSchedule.StartTime) {
+		checkMovie, err := svc.movieRepo.GetMovieById(scheduleUpdate.MovieID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", movieError.ErrDatabaseError, err)
 		}
 
-		existingStart, _ := time.Parse(layout, s.StartTime)
-		existingEnd, _ := time.Parse(layout, s.EndTime)
+		if checkMovie == nil {
+			return nil, fmt.Errorf("%w", movieError.ErrMovieNotFound)
+		}
 
-		if start.Before(existingEnd) && end.After(existingStart) {
-			return nil, fmt.Errorf("%w", customerror.ErrScheduleConflict)
+		if err := checkRunWindow(checkMovie, scheduleUpdate.StartTime, config.Location()); err != nil {
+			return nil, err
 		}
 	}
 
-	if *req.Price < 0 {
-		return nil, fmt.Errorf("%w", customerror.ErrPriceInput)
+	if !scheduleUpdate.StartTime.Before(scheduleUpdate.EndTime) {
+		return nil, fmt.Errorf("%w", customerror.ErrTimeStart)
+	}
+
+	if err := svc.checkConflict(scheduleUpdate.StudioID, scheduleUpdate.StartTime, scheduleUpdate.EndTime, idParse); err != nil {
+		return nil, err
 	}
 
-	scheduleUpdate := *existingSchedule
-	svc.applyUpdates(&scheduleUpdate, req)
 	scheduleUpdate.UpdatedAt = time.Now()
 
 	if err := svc.repo.Update(idParse, &scheduleUpdate); err != nil {
@@ -271,7 +247,22 @@ func (svc *svcSchedule) Delete(role, id string) error {
 }
 
 // Helper
+func (svc *svcSchedule) checkConflict(studioID uuid.UUID, start, end time.Time, excludeID uuid.UUID) error {
+	overlapping, err := svc.repo.FindOverlapping(studioID, start, end, excludeID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if len(overlapping) > 0 {
+		return fmt.Errorf("%w", customerror.ErrScheduleConflict)
+	}
+
+	return nil
+}
+
 func (svc *svcSchedule) toScheduleResponse(model *entities.Schedules) *dto.ScheduleResponse {
+	loc := config.Location()
+
 	return &dto.ScheduleResponse{
 		ID:             model.ID,
 		MovieId:        model.MovieID,
@@ -283,15 +274,34 @@ func (svc *svcSchedule) toScheduleResponse(model *entities.Schedules) *dto.Sched
 		StudioId:       model.StudioID,
 		StudioName:     model.Studio.Name,
 		StudioLocation: model.Studio.Location,
-		StartTime:      model.StartTime,
-		EndTime:        model.EndTime,
+		StartTime:      model.StartTime.In(loc),
+		EndTime:        model.EndTime.In(loc),
 		Price:          model.Price,
 		CreatedAt:      model.CreatedAt,
 		UpdatedAt:      model.UpdatedAt,
 	}
 }
 
-func (svc *svcSchedule) applyUpdates(schedule *entities.Schedules, req *dto.ScheduleUpdateRequest) {
+// checkRunWindow memastikan tanggal mulai tayang (di timezone bioskop) ada di dalam masa tayang movie
+func checkRunWindow(movie *movieEntity.Movies, start time.Time, loc *time.Location) error {
+	if movie.ShowingOn(start.In(loc)) {
+		return nil
+	}
+
+	var window []string
+	if movie.Release_Date != nil {
+		window = append(window, "from "+movie.Release_Date.Format(movieEntity.DateLayout))
+	}
+	if movie.End_Date != nil {
+		window = append(window, "until "+movie.End_Date.Format(movieEntity.DateLayout))
+	}
+
+	return fmt.Errorf("%w: %s runs %s", customerror.ErrOutsideRunWindow, movie.Title, strings.Join(window, " "))
+}
+
+func (svc *svcSchedule) applyUpdates(schedule *entities.Schedules, req *dto.ScheduleUpdateRequest) error {
+	loc := config.Location()
+
 	if req.MovieID != nil {
 		schedule.MovieID = *req.MovieID
 	}
@@ -299,12 +309,26 @@ func (svc *svcSchedule) applyUpdates(schedule *entities.Schedules, req *dto.Sche
 		schedule.StudioID = *req.StudioID
 	}
 	if req.StartTime != nil {
-		schedule.StartTime = strings.TrimSpace(*req.StartTime)
+		start, err := customtype.ParseShowtime(*req.StartTime, loc)
+		if err != nil {
+			return fmt.Errorf("%w: start_time", customerror.ErrInvalidShowtime)
+		}
+
+		// Jika hanya start_time yang diubah, durasi tayang dipertahankan
+		duration := schedule.EndTime.Sub(schedule.StartTime)
+		schedule.StartTime = start
+		schedule.EndTime = start.Add(duration)
 	}
 	if req.EndTime != nil {
-		schedule.EndTime = strings.TrimSpace(*req.EndTime)
+		end, err := customtype.ParseEndTime(*req.EndTime, schedule.StartTime, loc)
+		if err != nil {
+			return fmt.Errorf("%w: end_time", customerror.ErrInvalidShowtime)
+		}
+		schedule.EndTime = end
 	}
 	if req.Price != nil {
 		schedule.Price = *req.Price
 	}
+
+	return nil
 }
@@ -9,4 +9,8 @@ var (
 	ErrDatabaseError    = errors.New("database operation failed")
 	ErrInvalidStudioId  = errors.New("invalid studio id format")
 	ErrUnauthorizedUser = errors.New("forbidden user")
+	ErrSeatNotFound     = errors.New("seat not found")
+	ErrSeatExists       = errors.New("seat with this code already exists in the studio")
+	ErrInvalidLayout    = errors.New("invalid seat layout")
+	ErrSeatPositionUsed = errors.New("another seat already occupies this grid position")
 )
@@ -0,0 +1,41 @@
+package dto
+
+// SeatRowRequest mendeskripsikan satu baris kursi pada denah studio
+type SeatRowRequest struct {
+	// Label baris berupa huruf saja, misal "A" atau "AA", supaya kode kursi tidak bentrok (A1+2 vs A+12)
+	Label string `json:"label" validate:"required,min=1,max=5,alpha"`
+	// Jumlah kursi pada baris
+	Seats int `json:"seats" validate:"required,min=1,max=100"`
+	// Tipe default kursi pada baris (regular/vip/sweetbox/wheelchair)
+	Type string `json:"type,omitempty" validate:"omitempty,oneof=regular vip sweetbox wheelchair"`
+	// Jumlah kolom kosong sebelum kursi pertama
+	Offset int `json:"offset,omitempty" validate:"omitempty,min=0,max=50"`
+	// Jumlah baris kosong sebelum baris ini
+	GapRowsBefore int `json:"gap_rows_before,omitempty" validate:"omitempty,min=0,max=10"`
+	// Nomor kursi yang diikuti lorong, misal [4, 10]
+	AislesAfter []int `json:"aisles_after,omitempty" validate:"omitempty,dive,min=1"`
+	// Override tipe per nomor kursi, misal {"1": "wheelchair"}
+	SeatTypes map[string]string `json:"seat_types,omitempty" validate:"omitempty,dive,keys,numeric,endkeys,oneof=regular vip sweetbox wheelchair"`
+	// Nomor kursi yang diblokir (rusak, dll)
+	Blocked []int `json:"blocked,omitempty" validate:"omitempty,dive,min=1"`
+}
+
+type SeatLayoutRequest struct {
+	Rows []SeatRowRequest `json:"rows" validate:"required,min=1,max=50,dive"`
+}
+
+type CreateSeatRequest struct {
+	RowLabel    string `json:"row_label" validate:"required,min=1,max=5,alpha"`
+	Number      int    `json:"number" validate:"required,min=1,max=999"`
+	RowIndex    int    `json:"row_index" validate:"min=0"`
+	ColumnIndex int    `json:"column_index" validate:"min=0"`
+	Type        string `json:"type,omitempty" validate:"omitempty,oneof=regular vip sweetbox wheelchair"`
+	IsBlocked   bool   `json:"is_blocked"`
+}
+
+type UpdateSeatRequest struct {
+	Type        *string `json:"type,omitempty" validate:"omitempty,oneof=regular vip sweetbox wheelchair"`
+	IsBlocked   *bool   `json:"is_blocked,omitempty"`
+	RowIndex    *int    `json:"row_index,omitempty" validate:"omitempty,min=0"`
+	ColumnIndex *int    `json:"column_index,omitempty" validate:"omitempty,min=0"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+import "github.com/google/uuid"
+
+type SeatResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Code        string    `json:"code"`
+	RowLabel    string    `json:"row_label"`
+	Number      int       `json:"number"`
+	RowIndex    int       `json:"row_index"`
+	ColumnIndex int       `json:"column_index"`
+	Type        string    `json:"type"`
+	IsBlocked   bool      `json:"is_blocked"`
+}
+
+// SeatLayoutResponse berisi denah lengkap studio.
+// Rows/Columns adalah ukuran grid, posisi tanpa kursi adalah lorong/celah.
+type SeatLayoutResponse struct {
+	StudioID uuid.UUID      `json:"studio_id"`
+	Rows     int            `json:"rows"`
+	Columns  int            `json:"columns"`
+	Capacity int            `json:"capacity"`
+	Seats    []SeatResponse `json:"seats"`
+}
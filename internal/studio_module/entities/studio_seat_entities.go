@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SeatType string
+
+const (
+	SeatTypeRegular    SeatType = "regular"
+	SeatTypeVIP        SeatType = "vip"
+	SeatTypeSweetbox   SeatType = "sweetbox"
+	SeatTypeWheelchair SeatType = "wheelchair"
+)
+
+func (t SeatType) IsValid() bool {
+	switch t {
+	case SeatTypeRegular, SeatTypeVIP, SeatTypeSweetbox, SeatTypeWheelchair:
+		return true
+	default:
+		return false
+	}
+}
+
+// StudioSeat adalah satu kursi pada denah studio.
+// RowIndex/ColumnIndex adalah posisi di grid, kolom/baris yang kosong
+// dianggap lorong (aisle) atau celah (gap).
+type StudioSeat struct {
+	ID          uuid.UUID `gorm:"type:uuid; primaryKey" json:"id"`
+	StudioID    uuid.UUID `gorm:"type:uuid; not null; uniqueIndex:idx_studio_seat_code" json:"studio_id"`
+	Code        string    `gorm:"type:varchar(10); not null; uniqueIndex:idx_studio_seat_code" json:"code"`
+	RowLabel    string    `gorm:"type:varchar(5); not null" json:"row_label"`
+	Number      int       `gorm:"type:int; not null" json:"number"`
+	RowIndex    int       `gorm:"type:int; not null" json:"row_index"`
+	ColumnIndex int       `gorm:"type:int; not null" json:"column_index"`
+	Type        SeatType  `gorm:"type:varchar(20); not null; default:'regular'" json:"type"`
+	IsBlocked   bool      `gorm:"not null; default:false" json:"is_blocked"`
+	Created_At  time.Time `json:"created_at" gorm:"autoCreateTime"`
+	Updated_At  time.Time `json:"updated_at" gorm:"autoCreateTime; autoUpdateTime"`
+}
+
+func (StudioSeat) TableName() string {
+	return "studio_seats"
+}
@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"errors"
+	"movie-ticket/internal/middleware"
+	customerror "movie-ticket/internal/studio_module/custom_error"
+	"movie-ticket/internal/studio_module/dto"
+	"movie-ticket/internal/studio_module/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StudioSeatHandler struct {
+	service services.StudioSeatService
+}
+
+func NewStudioSeatHandlerAdmin(r *gin.RouterGroup, svc services.StudioSeatService) {
+	h := StudioSeatHandler{service: svc}
+	r.PUT("/studio/:id/seats", h.SetLayout)
+	r.DELETE("/studio/:id/seats", h.ClearLayout)
+	r.POST("/studio/:id/seats", h.CreateSeat)
+	r.PATCH("/studio/:id/seats/:code", h.UpdateSeat)
+	r.DELETE("/studio/:id/seats/:code", h.DeleteSeat)
+}
+
+func NewStudioSeatHandlerUser(r *gin.RouterGroup, svc services.StudioSeatService) {
+	h := StudioSeatHandler{service: svc}
+	r.GET("/studio/:id/seats", h.GetLayout)
+}
+
+// GetLayout godoc
+// @Summary Mendapatkan denah kursi studio
+// @Description Mengambil seluruh kursi studio beserta posisi baris/kolom di grid, tipe kursi, dan status blokir. Posisi grid tanpa kursi adalah lorong atau celah
+// @Tags Studio Seats
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Studio ID" format(uuid)
+// @Success 200 {object} dto.SeatLayoutResponse "Denah kursi berhasil diambil"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid studio ID"
+// @Failure 404 {object} map[string]interface{} "Not Found - Studio tidak ditemukan"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /studio/{id}/seats [get]
+// @Security BearerAuth
+func (h *StudioSeatHandler) GetLayout(c *gin.Context) {
+	layout, err := h.service.GetLayout(c.Param("id"))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, layout)
+}
+
+// SetLayout godoc
+// @Summary Membuat ulang denah kursi studio (Admin only)
+// @Description Mengganti seluruh denah kursi studio berdasarkan deskripsi baris (jumlah kursi, lorong, celah, tipe kursi, kursi diblokir). Seat capacity studio otomatis disesuaikan
+// @Tags Studio Seats
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Studio ID" format(uuid)
+// @Param request body dto.SeatLayoutRequest true "Seat layout data"
+// @Success 200 {object} dto.SeatLayoutResponse "Denah kursi berhasil disimpan"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid input atau denah tidak valid"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - User bukan admin"
+// @Failure 404 {object} map[string]interface{} "Not Found - Studio tidak ditemukan"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /admin/studio/{id}/seats [put]
+// @Security BearerAuth
+func (h *StudioSeatHandler) SetLayout(c *gin.Context) {
+	role, err := middleware.GetUserRoleFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized User!"})
+		return
+	}
+
+	var req dto.SeatLayoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON: " + err.Error()})
+		return
+	}
+
+	layout, err := h.service.SetLayout(role, c.Param("id"), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, layout)
+}
+
+// ClearLayout godoc
+// @Summary Menghapus seluruh denah kursi studio (Admin only)
+// @Description Menghapus semua kursi pada studio. Hanya admin yang dapat mengakses endpoint ini
+// @Tags Studio Seats
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Studio ID" format(uuid)
+// @Success 200 {object} map[string]interface{} "Denah kursi berhasil dihapus"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid studio ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - User bukan admin"
+// @Failure 404 {object} map[string]interface{} "Not Found - Studio tidak ditemukan"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /admin/studio/{id}/seats [delete]
+// @Security BearerAuth
+func (h *StudioSeatHandler) ClearLayout(c *gin.Context) {
+	role, err := middleware.GetUserRoleFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized User!"})
+		return
+	}
+
+	if err := h.service.ClearLayout(role, c.Param("id")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Seat layout deleted successfully"})
+}
+
+// CreateSeat godoc
+// @Summary Menambah satu kursi ke studio (Admin only)
+// @Description Menambahkan kursi baru pada posisi grid tertentu. Kode kursi dibentuk dari label baris (huruf saja) + nomor, misal A12
+// @Tags Studio Seats
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Studio ID" format(uuid)
+// @Param request body dto.CreateSeatRequest true "Seat data"
+// @Success 201 {object} dto.SeatResponse "Kursi berhasil ditambahkan"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - User bukan admin"
+// @Failure 404 {object} map[string]interface{} "Not Found - Studio tidak ditemukan"
+// @Failure 409 {object} map[string]interface{} "Conflict - Kode kursi sudah ada atau posisi grid sudah dipakai kursi lain"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /admin/studio/{id}/seats [post]
+// @Security BearerAuth
+func (h *StudioSeatHandler) CreateSeat(c *gin.Context) {
+	role, err := middleware.GetUserRoleFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized User!"})
+		return
+	}
+
+	var req dto.CreateSeatRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON: " + err.Error()})
+		return
+	}
+
+	seat, err := h.service.CreateSeat(role, c.Param("id"), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, seat)
+}
+
+// UpdateSeat godoc
+// @Summary Update kursi studio (Admin only)
+// @Description Mengubah tipe kursi, status blokir, atau posisi grid kursi berdasarkan kode kursi
+// @Tags Studio Seats
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Studio ID" format(uuid)
+// @Param code path string true "Kode kursi, misal A12"
+// @Param request body dto.UpdateSeatRequest true "Seat update data"
+// @Success 200 {object} dto.SeatResponse "Kursi berhasil diupdate"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid input"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - User bukan admin"
+// @Failure 404 {object} map[string]interface{} "Not Found - Studio atau kursi tidak ditemukan"
+// @Failure 409 {object} map[string]interface{} "Conflict - Posisi grid sudah dipakai kursi lain"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /admin/studio/{id}/seats/{code} [patch]
+// @Security BearerAuth
+func (h *StudioSeatHandler) UpdateSeat(c *gin.Context) {
+	role, err := middleware.GetUserRoleFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized User!"})
+		return
+	}
+
+	var req dto.UpdateSeatRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON: " + err.Error()})
+		return
+	}
+
+	seat, err := h.service.UpdateSeat(role, c.Param("id"), c.Param("code"), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, seat)
+}
+
+// DeleteSeat godoc
+// @Summary Hapus kursi studio (Admin only)
+// @Description Menghapus satu kursi berdasarkan kode kursi. Seat capacity studio otomatis disesuaikan
+// @Tags Studio Seats
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Studio ID" format(uuid)
+// @Param code path string true "Kode kursi, misal A12"
+// @Success 200 {object} map[string]interface{} "Kursi berhasil dihapus"
+// @Failure 400 {object} map[string]interface{} "Bad Request - Invalid studio ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized - User bukan admin"
+// @Failure 404 {object} map[string]interface{} "Not Found - Studio atau kursi tidak ditemukan"
+// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Router /admin/studio/{id}/seats/{code} [delete]
+// @Security BearerAuth
+func (h *StudioSeatHandler) DeleteSeat(c *gin.Context) {
+	role, err := middleware.GetUserRoleFromRedis(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized User!"})
+		return
+	}
+
+	if err := h.service.DeleteSeat(role, c.Param("id"), c.Param("code")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Seat deleted successfully"})
+}
+
+func (h *StudioSeatHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, customerror.ErrUnauthorizedUser):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, customerror.ErrInvalidInput),
+		errors.Is(err, customerror.ErrInvalidStudioId),
+		errors.Is(err, customerror.ErrInvalidLayout):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, customerror.ErrStudioNotFound),
+		errors.Is(err, customerror.ErrSeatNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, customerror.ErrSeatExists), errors.Is(err, customerror.ErrSeatPositionUsed):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -64,9 +64,11 @@ func (r *studioRepo) GetById(id uuid.UUID) (*entities.Studio, error) {
 	var studio *entities.Studio
 
 	err := postgres.DB.Where("id = ?", id).First(&studio).Error
-
-	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, fmt.Errorf("data not found: %w", err)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
 	}
 
 	return studio, nil
@@ -0,0 +1,153 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"movie-ticket/infra/postgres"
+	"movie-ticket/internal/studio_module/entities"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type StudioSeatRepository interface {
+	GetByStudio(studioID uuid.UUID) ([]entities.StudioSeat, error)
+	GetByCode(studioID uuid.UUID, code string) (*entities.StudioSeat, error)
+	GetByPosition(studioID uuid.UUID, rowIndex, columnIndex int) (*entities.StudioSeat, error)
+	ReplaceLayout(studioID uuid.UUID, seats []entities.StudioSeat) error
+	Create(seat *entities.StudioSeat) error
+	Update(seat *entities.StudioSeat) error
+	Delete(studioID uuid.UUID, code string) error
+	DeleteByStudio(studioID uuid.UUID) error
+}
+
+type studioSeatRepo struct{}
+
+func NewStudioSeatRepo() StudioSeatRepository {
+	return &studioSeatRepo{}
+}
+
+func (r *studioSeatRepo) GetByStudio(studioID uuid.UUID) ([]entities.StudioSeat, error) {
+	var seats []entities.StudioSeat
+
+	err := postgres.DB.Where("studio_id = ?", studioID).
+		Order("row_index ASC, column_index ASC").
+		Find(&seats).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seats: %w", err)
+	}
+
+	return seats, nil
+}
+
+func (r *studioSeatRepo) GetByCode(studioID uuid.UUID, code string) (*entities.StudioSeat, error) {
+	var seat entities.StudioSeat
+
+	err := postgres.DB.Where("studio_id = ? AND code = ?", studioID, code).First(&seat).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &seat, nil
+}
+
+// GetByPosition mengembalikan nil jika tidak ada kursi di posisi grid tersebut
+func (r *studioSeatRepo) GetByPosition(studioID uuid.UUID, rowIndex, columnIndex int) (*entities.StudioSeat, error) {
+	var seat entities.StudioSeat
+
+	err := postgres.DB.Where("studio_id = ? AND row_index = ? AND column_index = ?", studioID, rowIndex, columnIndex).First(&seat).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &seat, nil
+}
+
+// ReplaceLayout menghapus denah lama dan menyimpan denah baru dalam satu transaksi,
+// sekaligus menyamakan seat_capacity studio dengan jumlah kursi
+func (r *studioSeatRepo) ReplaceLayout(studioID uuid.UUID, seats []entities.StudioSeat) error {
+	return postgres.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("studio_id = ?", studioID).Delete(&entities.StudioSeat{}).Error; err != nil {
+			return fmt.Errorf("failed to delete old layout: %w", err)
+		}
+
+		if len(seats) > 0 {
+			if err := tx.Create(&seats).Error; err != nil {
+				return fmt.Errorf("failed to create layout: %w", err)
+			}
+		}
+
+		return syncSeatCapacity(tx, studioID)
+	})
+}
+
+func (r *studioSeatRepo) Create(seat *entities.StudioSeat) error {
+	return postgres.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(seat).Error; err != nil {
+			return fmt.Errorf("failed to create seat: %w", err)
+		}
+
+		return syncSeatCapacity(tx, seat.StudioID)
+	})
+}
+
+func (r *studioSeatRepo) Update(seat *entities.StudioSeat) error {
+	updates := map[string]interface{}{
+		"type":         seat.Type,
+		"is_blocked":   seat.IsBlocked,
+		"row_index":    seat.RowIndex,
+		"column_index": seat.ColumnIndex,
+		"updated_at":   time.Now(),
+	}
+
+	result := postgres.DB.Model(&entities.StudioSeat{}).
+		Where("id = ?", seat.ID).
+		Updates(updates)
+
+	if result.Error != nil {
+		return fmt.Errorf("failed during seat update: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("no changed data")
+	}
+
+	return nil
+}
+
+func (r *studioSeatRepo) Delete(studioID uuid.UUID, code string) error {
+	return postgres.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("studio_id = ? AND code = ?", studioID, code).Delete(&entities.StudioSeat{}).Error; err != nil {
+			return fmt.Errorf("failed to delete seat: %w", err)
+		}
+
+		return syncSeatCapacity(tx, studioID)
+	})
+}
+
+func (r *studioSeatRepo) DeleteByStudio(studioID uuid.UUID) error {
+	return r.ReplaceLayout(studioID, nil)
+}
+
+func syncSeatCapacity(tx *gorm.DB, studioID uuid.UUID) error {
+	var count int64
+	if err := tx.Model(&entities.StudioSeat{}).Where("studio_id = ?", studioID).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to count seats: %w", err)
+	}
+
+	err := tx.Model(&entities.Studio{}).
+		Where("id = ?", studioID).
+		Updates(map[string]interface{}{"seat_capacity": count, "updated_at": time.Now()}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update seat capacity: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,390 @@
+package services
+
+import (
+	"fmt"
+	customerror "movie-ticket/internal/studio_module/custom_error"
+	"movie-ticket/internal/studio_module/dto"
+	"movie-ticket/internal/studio_module/entities"
+	"movie-ticket/internal/studio_module/repositories"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type StudioSeatService interface {
+	GetLayout(studioID string) (*dto.SeatLayoutResponse, error)
+	SetLayout(role, studioID string, req *dto.SeatLayoutRequest) (*dto.SeatLayoutResponse, error)
+	ClearLayout(role, studioID string) error
+	CreateSeat(role, studioID string, req *dto.CreateSeatRequest) (*dto.SeatResponse, error)
+	UpdateSeat(role, studioID, code string, req *dto.UpdateSeatRequest) (*dto.SeatResponse, error)
+	DeleteSeat(role, studioID, code string) error
+}
+
+type studioSeatSvc struct {
+	repo       repositories.StudioSeatRepository
+	studioRepo repositories.StudioRepository
+	validate   *validator.Validate
+}
+
+func NewStudioSeatService(r repositories.StudioSeatRepository, studioRepo repositories.StudioRepository) StudioSeatService {
+	return &studioSeatSvc{
+		repo:       r,
+		studioRepo: studioRepo,
+		validate:   validator.New(),
+	}
+}
+
+func (s *studioSeatSvc) GetLayout(studioID string) (*dto.SeatLayoutResponse, error) {
+	idParse, err := s.findStudio(studioID)
+	if err != nil {
+		return nil, err
+	}
+
+	seats, err := s.repo.GetByStudio(idParse)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	return toSeatLayoutResponse(idParse, seats), nil
+}
+
+func (s *studioSeatSvc) SetLayout(role, studioID string, req *dto.SeatLayoutRequest) (*dto.SeatLayoutResponse, error) {
+	if role != "admin" {
+		return nil, fmt.Errorf("%w", customerror.ErrUnauthorizedUser)
+	}
+
+	if req == nil {
+		return nil, fmt.Errorf("%w", customerror.ErrInvalidInput)
+	}
+
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrInvalidLayout, formatValidationError(err))
+	}
+
+	idParse, err := s.findStudio(studioID)
+	if err != nil {
+		return nil, err
+	}
+
+	seats, err := buildLayout(idParse, req.Rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.ReplaceLayout(idParse, seats); err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	return toSeatLayoutResponse(idParse, seats), nil
+}
+
+func (s *studioSeatSvc) ClearLayout(role, studioID string) error {
+	if role != "admin" {
+		return fmt.Errorf("%w", customerror.ErrUnauthorizedUser)
+	}
+
+	idParse, err := s.findStudio(studioID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteByStudio(idParse); err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	return nil
+}
+
+func (s *studioSeatSvc) CreateSeat(role, studioID string, req *dto.CreateSeatRequest) (*dto.SeatResponse, error) {
+	if role != "admin" {
+		return nil, fmt.Errorf("%w", customerror.ErrUnauthorizedUser)
+	}
+
+	if req == nil {
+		return nil, fmt.Errorf("%w", customerror.ErrInvalidInput)
+	}
+
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrInvalidInput, formatValidationError(err))
+	}
+
+	idParse, err := s.findStudio(studioID)
+	if err != nil {
+		return nil, err
+	}
+
+	label := strings.ToUpper(strings.TrimSpace(req.RowLabel))
+	code := seatCode(label, req.Number)
+
+	existing, err := s.repo.GetByCode(idParse, code)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if existing != nil {
+		return nil, fmt.Errorf("%w: %s", customerror.ErrSeatExists, code)
+	}
+
+	if err := s.checkPositionFree(idParse, req.RowIndex, req.ColumnIndex, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	seatType := entities.SeatTypeRegular
+	if req.Type != "" {
+		seatType = entities.SeatType(req.Type)
+	}
+
+	seat := &entities.StudioSeat{
+		ID:          uuid.New(),
+		StudioID:    idParse,
+		Code:        code,
+		RowLabel:    label,
+		Number:      req.Number,
+		RowIndex:    req.RowIndex,
+		ColumnIndex: req.ColumnIndex,
+		Type:        seatType,
+		IsBlocked:   req.IsBlocked,
+		Created_At:  time.Now(),
+		Updated_At:  time.Now(),
+	}
+
+	if err := s.repo.Create(seat); err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	response := toSeatResponse(seat)
+	return &response, nil
+}
+
+func (s *studioSeatSvc) UpdateSeat(role, studioID, code string, req *dto.UpdateSeatRequest) (*dto.SeatResponse, error) {
+	if role != "admin" {
+		return nil, fmt.Errorf("%w", customerror.ErrUnauthorizedUser)
+	}
+
+	if req == nil {
+		return nil, fmt.Errorf("%w", customerror.ErrInvalidInput)
+	}
+
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrInvalidInput, formatValidationError(err))
+	}
+
+	idParse, err := s.findStudio(studioID)
+	if err != nil {
+		return nil, err
+	}
+
+	seat, err := s.repo.GetByCode(idParse, strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if seat == nil {
+		return nil, fmt.Errorf("%w", customerror.ErrSeatNotFound)
+	}
+
+	if req.Type != nil {
+		seat.Type = entities.SeatType(*req.Type)
+	}
+	if req.IsBlocked != nil {
+		seat.IsBlocked = *req.IsBlocked
+	}
+	if req.RowIndex != nil {
+		seat.RowIndex = *req.RowIndex
+	}
+	if req.ColumnIndex != nil {
+		seat.ColumnIndex = *req.ColumnIndex
+	}
+
+	if req.RowIndex != nil || req.ColumnIndex != nil {
+		if err := s.checkPositionFree(idParse, seat.RowIndex, seat.ColumnIndex, seat.ID); err != nil {
+			return nil, err
+		}
+	}
+	seat.Updated_At = time.Now()
+
+	if err := s.repo.Update(seat); err != nil {
+		return nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	response := toSeatResponse(seat)
+	return &response, nil
+}
+
+func (s *studioSeatSvc) DeleteSeat(role, studioID, code string) error {
+	if role != "admin" {
+		return fmt.Errorf("%w", customerror.ErrUnauthorizedUser)
+	}
+
+	idParse, err := s.findStudio(studioID)
+	if err != nil {
+		return err
+	}
+
+	code = strings.ToUpper(strings.TrimSpace(code))
+	seat, err := s.repo.GetByCode(idParse, code)
+	if err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if seat == nil {
+		return fmt.Errorf("%w", customerror.ErrSeatNotFound)
+	}
+
+	if err := s.repo.Delete(idParse, code); err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	return nil
+}
+
+// Helper Service
+func (s *studioSeatSvc) findStudio(studioID string) (uuid.UUID, error) {
+	idParse, err := uuid.Parse(studioID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w", customerror.ErrInvalidStudioId)
+	}
+
+	studio, err := s.studioRepo.GetById(idParse)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if studio == nil {
+		return uuid.Nil, fmt.Errorf("%w", customerror.ErrStudioNotFound)
+	}
+
+	return idParse, nil
+}
+
+// checkPositionFree memastikan posisi grid belum dipakai kursi lain selain seatID
+func (s *studioSeatSvc) checkPositionFree(studioID uuid.UUID, rowIndex, columnIndex int, seatID uuid.UUID) error {
+	other, err := s.repo.GetByPosition(studioID, rowIndex, columnIndex)
+	if err != nil {
+		return fmt.Errorf("%w: %v", customerror.ErrDatabaseError, err)
+	}
+
+	if other != nil && other.ID != seatID {
+		return fmt.Errorf("%w: row %d column %d is seat %s", customerror.ErrSeatPositionUsed, rowIndex, columnIndex, other.Code)
+	}
+
+	return nil
+}
+
+// buildLayout mengubah deskripsi baris menjadi daftar kursi beserta posisinya di grid
+func buildLayout(studioID uuid.UUID, rows []dto.SeatRowRequest) ([]entities.StudioSeat, error) {
+	seats := make([]entities.StudioSeat, 0)
+	seenLabels := make(map[string]bool, len(rows))
+	now := time.Now()
+
+	rowIndex := 0
+	for _, row := range rows {
+		label := strings.ToUpper(strings.TrimSpace(row.Label))
+		if seenLabels[label] {
+			return nil, fmt.Errorf("%w: duplicate row label %s", customerror.ErrInvalidLayout, label)
+		}
+		seenLabels[label] = true
+
+		aisles := make(map[int]bool, len(row.AislesAfter))
+		for _, n := range row.AislesAfter {
+			if n >= row.Seats {
+				return nil, fmt.Errorf("%w: aisle after seat %d is outside row %s", customerror.ErrInvalidLayout, n, label)
+			}
+			aisles[n] = true
+		}
+
+		blocked := make(map[int]bool, len(row.Blocked))
+		for _, n := range row.Blocked {
+			if n > row.Seats {
+				return nil, fmt.Errorf("%w: blocked seat %d is outside row %s", customerror.ErrInvalidLayout, n, label)
+			}
+			blocked[n] = true
+		}
+
+		overrides := make(map[int]entities.SeatType, len(row.SeatTypes))
+		for key, value := range row.SeatTypes {
+			n, err := strconv.Atoi(key)
+			if err != nil || n < 1 || n > row.Seats {
+				return nil, fmt.Errorf("%w: seat type override %s is outside row %s", customerror.ErrInvalidLayout, key, label)
+			}
+			overrides[n] = entities.SeatType(value)
+		}
+
+		rowType := entities.SeatTypeRegular
+		if row.Type != "" {
+			rowType = entities.SeatType(row.Type)
+		}
+
+		rowIndex += row.GapRowsBefore
+		column := row.Offset
+		for n := 1; n <= row.Seats; n++ {
+			seatType := rowType
+			if override, ok := overrides[n]; ok {
+				seatType = override
+			}
+
+			seats = append(seats, entities.StudioSeat{
+				ID:          uuid.New(),
+				StudioID:    studioID,
+				Code:        seatCode(label, n),
+				RowLabel:    label,
+				Number:      n,
+				RowIndex:    rowIndex,
+				ColumnIndex: column,
+				Type:        seatType,
+				IsBlocked:   blocked[n],
+				Created_At:  now,
+				Updated_At:  now,
+			})
+
+			column++
+			if aisles[n] {
+				column++
+			}
+		}
+		rowIndex++
+	}
+
+	return seats, nil
+}
+
+// seatCode menggabungkan label baris (huruf saja) dan nomor kursi, misal A12
+func seatCode(rowLabel string, number int) string {
+	return fmt.Sprintf("%s%d", rowLabel, number)
+}
+
+func toSeatResponse(seat *entities.StudioSeat) dto.SeatResponse {
+	return dto.SeatResponse{
+		ID:          seat.ID,
+		Code:        seat.Code,
+		RowLabel:    seat.RowLabel,
+		Number:      seat.Number,
+		RowIndex:    seat.RowIndex,
+		ColumnIndex: seat.ColumnIndex,
+		Type:        string(seat.Type),
+		IsBlocked:   seat.IsBlocked,
+	}
+}
+
+func toSeatLayoutResponse(studioID uuid.UUID, seats []entities.StudioSeat) *dto.SeatLayoutResponse {
+	layout := &dto.SeatLayoutResponse{
+		StudioID: studioID,
+		Capacity: len(seats),
+		Seats:    make([]dto.SeatResponse, 0, len(seats)),
+	}
+
+	for i := range seats {
+		if seats[i].RowIndex+1 > layout.Rows {
+			layout.Rows = seats[i].RowIndex + 1
+		}
+		if seats[i].ColumnIndex+1 > layout.Columns {
+			layout.Columns = seats[i].ColumnIndex + 1
+		}
+		layout.Seats = append(layout.Seats, toSeatResponse(&seats[i]))
+	}
+
+	return layout
+}
@@ -0,0 +1,22 @@
+package services
+
+import "testing"
+
+func TestSeatCode(t *testing.T) {
+	tests := []struct {
+		label  string
+		number int
+		want   string
+	}{
+		{"A", 1, "A1"},
+		{"A", 12, "A12"},
+		{"AA", 3, "AA3"},
+		{"VIP", 999, "VIP999"},
+	}
+
+	for _, tt := range tests {
+		if got := seatCode(tt.label, tt.number); got != tt.want {
+			t.Errorf("seatCode(%q, %d) = %q, want %q", tt.label, tt.number, got, tt.want)
+		}
+	}
+}
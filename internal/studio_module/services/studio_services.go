@@ -44,7 +44,7 @@ func (s *studioSvc) Create(role string, req *dto.CreateStudioRequest) (*dto.Stud
 	}
 
 	if err := s.validate.Struct(req); err != nil {
-		return nil, s.formatValidationError(err)
+		return nil, formatValidationError(err)
 	}
 
 	existingStudio, err := s.repo.GetByName(req.Name)
@@ -140,7 +140,7 @@ func (s *studioSvc) Update(role, id string, input *dto.UpdateStudioRequest) (*dt
 	}
 
 	if err := s.validate.Struct(input); err != nil {
-		return nil, s.formatValidationError(err)
+		return nil, formatValidationError(err)
 	}
 
 	existingStudio, err := s.repo.GetById(idParse)
@@ -202,7 +202,7 @@ func (s *studioSvc) toStudioResponse(studio *entities.Studio) *dto.StudioRespons
 	}
 }
 
-func (s *studioSvc) formatValidationError(err error) error {
+func formatValidationError(err error) error {
 	var errorMessages []string
 
 	for _, err := range err.(validator.ValidationErrors) {
@@ -213,6 +213,10 @@ func (s *studioSvc) formatValidationError(err error) error {
 			errorMessages = append(errorMessages, fmt.Sprintf("%s must be at least %s characters/value", strings.ToLower(err.Field()), err.Param()))
 		case "max":
 			errorMessages = append(errorMessages, fmt.Sprintf("%s must be at most %s characters/value", strings.ToLower(err.Field()), err.Param()))
+		case "oneof":
+			errorMessages = append(errorMessages, fmt.Sprintf("%s must be one of: %s", strings.ToLower(err.Field()), err.Param()))
+		case "alpha":
+			errorMessages = append(errorMessages, fmt.Sprintf("%s must contain letters only", strings.ToLower(err.Field())))
 		default:
 			errorMessages = append(errorMessages, fmt.Sprintf("%s is invalid", strings.ToLower(err.Field())))
 		}
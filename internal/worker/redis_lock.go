@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Hanya hapus lock jika token masih milik kita, supaya tidak menghapus
+// lock replica lain ketika TTL kita sudah habis
+const unlockScript = `
+	if redis.call('GET', KEYS[1]) == ARGV[1] then
+		return redis.call('DEL', KEYS[1])
+	end
+	return 0
+`
+
+// redisLock adalah lock sederhana berbasis SET NX PX untuk memastikan
+// hanya satu replica yang menjalankan job pada satu waktu
+type redisLock struct {
+	redis *redis.Client
+	key   string
+	ttl   time.Duration
+}
+
+func newRedisLock(r *redis.Client, key string, ttl time.Duration) *redisLock {
+	return &redisLock{redis: r, key: key, ttl: ttl}
+}
+
+// Acquire mengembalikan token jika lock berhasil diambil, string kosong jika dipegang replica lain
+func (l *redisLock) Acquire(ctx context.Context) (string, error) {
+	token := uuid.NewString()
+
+	ok, err := l.redis.SetNX(ctx, l.key, token, l.ttl).Result()
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", nil
+	}
+
+	return token, nil
+}
+
+func (l *redisLock) Release(ctx context.Context, token string) error {
+	return l.redis.Eval(ctx, unlockScript, []string{l.key}, token).Err()
+}
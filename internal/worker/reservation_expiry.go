@@ -0,0 +1,104 @@
+package worker
+
+import (
+	"context"
+	"log"
+	service "movie-ticket/internal/reservation_module/services"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	reservationExpiryLockKey = "lock:reservation-expiry"
+	// Batas waktu satu kali cleanup; lock ikut dilepas otomatis setelah ini
+	reservationExpiryTimeout = time.Minute
+)
+
+// ReservationExpiryWorker menjalankan CleanupExpiredReservations secara berkala.
+// Lock Redis memastikan hanya satu replica yang bekerja di setiap putaran.
+type ReservationExpiryWorker struct {
+	service  service.ReservationService
+	lock     *redisLock
+	interval time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewReservationExpiryWorker(svc service.ReservationService, r *redis.Client, interval time.Duration) *ReservationExpiryWorker {
+	return &ReservationExpiryWorker{
+		service:  svc,
+		lock:     newRedisLock(r, reservationExpiryLockKey, reservationExpiryTimeout),
+		interval: interval,
+	}
+}
+
+// Start menjalankan worker di goroutine terpisah sampai Stop dipanggil
+func (w *ReservationExpiryWorker) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		log.Printf("Reservation expiry worker berjalan setiap %s", w.interval)
+
+		for {
+			w.runOnce(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop menghentikan worker dan menunggu putaran yang sedang berjalan selesai
+func (w *ReservationExpiryWorker) Stop() {
+	if w.cancel == nil {
+		return
+	}
+	w.cancel()
+	w.wg.Wait()
+	log.Println("Reservation expiry worker berhenti")
+}
+
+func (w *ReservationExpiryWorker) runOnce(parent context.Context) {
+	if parent.Err() != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(parent, reservationExpiryTimeout)
+	defer cancel()
+
+	token, err := w.lock.Acquire(ctx)
+	if err != nil {
+		log.Printf("Warning: failed to acquire reservation expiry lock: %v", err)
+		return
+	}
+	if token == "" {
+		// Replica lain sedang menjalankan cleanup
+		return
+	}
+
+	defer func() {
+		// Pakai context baru agar lock tetap dilepas walau parent sudah di-cancel
+		releaseCtx, releaseCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer releaseCancel()
+		if err := w.lock.Release(releaseCtx, token); err != nil {
+			log.Printf("Warning: failed to release reservation expiry lock: %v", err)
+		}
+	}()
+
+	if err := w.service.CleanupExpiredReservations(ctx); err != nil {
+		log.Printf("Warning: reservation expiry cleanup failed: %v", err)
+	}
+}